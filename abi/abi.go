@@ -0,0 +1,315 @@
+// Package abi encodes and decodes Solidity ABI data for TRON smart
+// contracts.
+//
+// ABIs are parsed from the usual JSON form as well as the form returned by
+// /wallet/getcontract. Addresses are trongrid.Address values, integers
+// are decoded as *big.Int, bytes and fixed bytes as []byte, and arrays and
+// tuples as []interface{}.
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Argument is an input or output of a method or event.
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+type Arguments []Argument
+
+type argumentJSON struct {
+	Name       string         `json:"name"`
+	Type       string         `json:"type"`
+	Indexed    bool           `json:"indexed"`
+	Components []argumentJSON `json:"components"`
+}
+
+func (a *Argument) UnmarshalJSON(b []byte) error {
+
+	var raw argumentJSON
+	err := json.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	}
+
+	argument, err := raw.argument()
+	if err != nil {
+		return err
+	}
+
+	*a = argument
+
+	return nil
+}
+
+func (a argumentJSON) argument() (Argument, error) {
+
+	var components Arguments
+	for _, c := range a.Components {
+		component, err := c.argument()
+		if err != nil {
+			return Argument{}, err
+		}
+
+		components = append(components, component)
+	}
+
+	t, err := NewType(a.Type, components)
+	if err != nil {
+		return Argument{}, fmt.Errorf("argument %q: %w", a.Name, err)
+	}
+
+	return Argument{Name: a.Name, Type: t, Indexed: a.Indexed}, nil
+}
+
+// types returns the comma-separated canonical types of the arguments.
+func (a Arguments) types() string {
+
+	types := make([]string, len(a))
+	for i, argument := range a {
+		types[i] = argument.Type.String()
+	}
+
+	return strings.Join(types, ",")
+}
+
+// NonIndexed returns the arguments that are not indexed event topics.
+func (a Arguments) NonIndexed() Arguments {
+
+	var nonIndexed Arguments
+	for _, argument := range a {
+		if !argument.Indexed {
+			nonIndexed = append(nonIndexed, argument)
+		}
+	}
+
+	return nonIndexed
+}
+
+// Method is a contract function.
+type Method struct {
+	Name            string
+	Inputs          Arguments
+	Outputs         Arguments
+	StateMutability string
+}
+
+// Signature returns the function signature, e.g.
+// "transfer(address,uint256)", which is the FunctionSelector of contract
+// calls.
+func (m *Method) Signature() string {
+	return m.Name + "(" + m.Inputs.types() + ")"
+}
+
+// ID returns the 4-byte selector of the method.
+func (m *Method) ID() []byte {
+	return keccak256([]byte(m.Signature()))[:4]
+}
+
+// IsConstant reports whether the method can be called with
+// TriggerConstantContract without a transaction.
+func (m *Method) IsConstant() bool {
+	return m.StateMutability == "view" || m.StateMutability == "pure"
+}
+
+// Pack returns the call data of the method: its selector followed by the
+// encoded arguments.
+func (m *Method) Pack(args ...interface{}) ([]byte, error) {
+
+	encoded, err := m.Inputs.Encode(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.Name, err)
+	}
+
+	return append(m.ID(), encoded...), nil
+}
+
+// Unpack decodes the return data of the method.
+func (m *Method) Unpack(data []byte) ([]interface{}, error) {
+	return m.Outputs.Decode(data)
+}
+
+// Event is a contract event.
+type Event struct {
+	Name      string
+	Inputs    Arguments
+	Anonymous bool
+}
+
+// Signature returns the event signature, e.g.
+// "Transfer(address,address,uint256)".
+func (e *Event) Signature() string {
+	return e.Name + "(" + e.Inputs.types() + ")"
+}
+
+// ID returns the hash of the signature, the first topic of the logs of
+// non-anonymous events.
+func (e *Event) ID() []byte {
+	return keccak256([]byte(e.Signature()))
+}
+
+// ABI is the interface of a contract. Overloaded methods and events get
+// their index appended to their name from the second one on, e.g.
+// "transfer0".
+type ABI struct {
+	Constructor *Method
+	Methods     map[string]*Method
+	Events      map[string]*Event
+}
+
+type entryJSON struct {
+	Type            string         `json:"type"`
+	Name            string         `json:"name"`
+	Inputs          []argumentJSON `json:"inputs"`
+	Outputs         []argumentJSON `json:"outputs"`
+	StateMutability string         `json:"stateMutability"`
+	Constant        bool           `json:"constant"`
+	Anonymous       bool           `json:"anonymous"`
+}
+
+// Parse parses a JSON ABI. Besides the usual array of entries it accepts
+// the {"entrys": [...]} object of /wallet/getcontract, with or without
+// the enclosing contract, and its capitalized entry types and state
+// mutabilities.
+func Parse(b []byte) (*ABI, error) {
+
+	var entries []entryJSON
+
+	err := json.Unmarshal(b, &entries)
+	if err != nil {
+		var wrapped struct {
+			Entrys []entryJSON `json:"entrys"`
+			ABI    *struct {
+				Entrys []entryJSON `json:"entrys"`
+			} `json:"abi"`
+		}
+
+		if json.Unmarshal(b, &wrapped) != nil {
+			return nil, fmt.Errorf("invalid ABI: %w", err)
+		}
+
+		entries = wrapped.Entrys
+		if wrapped.ABI != nil {
+			entries = wrapped.ABI.Entrys
+		}
+	}
+
+	abi := &ABI{
+		Methods: make(map[string]*Method),
+		Events:  make(map[string]*Event),
+	}
+
+	for _, entry := range entries {
+		inputs, err := arguments(entry.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name, err)
+		}
+
+		outputs, err := arguments(entry.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name, err)
+		}
+
+		stateMutability := strings.ToLower(entry.StateMutability)
+		if stateMutability == "" && entry.Constant {
+			stateMutability = "view"
+		}
+
+		switch strings.ToLower(entry.Type) {
+		case "function", "":
+			method := &Method{Name: entry.Name, Inputs: inputs, Outputs: outputs, StateMutability: stateMutability}
+			abi.Methods[uniqueName(entry.Name, abi.Methods)] = method
+		case "constructor":
+			abi.Constructor = &Method{Inputs: inputs, StateMutability: stateMutability}
+		case "event":
+			event := &Event{Name: entry.Name, Inputs: inputs, Anonymous: entry.Anonymous}
+			abi.Events[uniqueName(entry.Name, abi.Events)] = event
+		}
+	}
+
+	return abi, nil
+}
+
+// MustParse is like Parse but panics on invalid ABIs.
+func MustParse(s string) *ABI {
+
+	abi, err := Parse([]byte(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return abi
+}
+
+func arguments(raw []argumentJSON) (Arguments, error) {
+
+	args := make(Arguments, 0, len(raw))
+	for _, r := range raw {
+		argument, err := r.argument()
+		if err != nil {
+			return nil, err
+		}
+
+		args = append(args, argument)
+	}
+
+	return args, nil
+}
+
+func uniqueName[T any](name string, taken map[string]T) string {
+
+	unique := name
+	for i := 0; ; i++ {
+		if _, ok := taken[unique]; !ok {
+			return unique
+		}
+
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+}
+
+// Method returns the method called name.
+func (a *ABI) Method(name string) (*Method, error) {
+
+	method, ok := a.Methods[name]
+	if !ok {
+		return nil, fmt.Errorf("no method %q in ABI", name)
+	}
+
+	return method, nil
+}
+
+// Pack returns the call data of the method called name.
+func (a *ABI) Pack(name string, args ...interface{}) ([]byte, error) {
+
+	method, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Pack(args...)
+}
+
+// Unpack decodes the return data of the method called name.
+func (a *ABI) Unpack(name string, data []byte) ([]interface{}, error) {
+
+	method, err := a.Method(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Unpack(data)
+}
+
+func keccak256(b []byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	h.Write(b)
+	return h.Sum(nil)
+}
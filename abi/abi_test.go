@@ -0,0 +1,284 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/TheTeaParty/trongrid"
+)
+
+const trc20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+	{"type":"function","name":"balanceOf","inputs":[{"name":"who","type":"address"}],"outputs":[{"name":"","type":"uint256"}],"constant":true},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256"}]}
+]`
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func mustArguments(t *testing.T, types ...string) Arguments {
+	t.Helper()
+
+	args := make(Arguments, len(types))
+	for i, s := range types {
+		typ, err := NewType(s, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		args[i] = Argument{Type: typ}
+	}
+
+	return args
+}
+
+func TestTRC20(t *testing.T) {
+
+	abi := MustParse(trc20ABI)
+
+	to := trongrid.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	want := mustHex(t, `
+		a9059cbb
+		000000000000000000000000a614f803b6fd780986a42c78ec9c7f77e6ded13c
+		00000000000000000000000000000000000000000000000000000000000f4240`)
+
+	method, err := abi.Method("transfer")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if method.Signature() != "transfer(address,uint256)" {
+		t.Errorf("Signature = %s", method.Signature())
+	}
+
+	if method.IsConstant() {
+		t.Error("transfer is constant")
+	}
+
+	// The address is accepted in any form ParseAddress accepts.
+	for _, arg := range []interface{}{to, to.String(), to.Hex(), "0xa614f803b6fd780986a42c78ec9c7f77e6ded13c"} {
+		data, err := abi.Pack("transfer", arg, 1_000_000)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(data, want) {
+			t.Errorf("Pack(transfer, %v) = %x, want %x", arg, data, want)
+		}
+	}
+
+	values, err := method.Inputs.Decode(want[4:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if values[0] != to || values[1].(*big.Int).Int64() != 1_000_000 {
+		t.Errorf("Decode = %v", values)
+	}
+
+	balanceOf, err := abi.Method("balanceOf")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !balanceOf.IsConstant() || hex.EncodeToString(balanceOf.ID()) != "70a08231" {
+		t.Errorf("balanceOf: constant %t, ID %x", balanceOf.IsConstant(), balanceOf.ID())
+	}
+
+	event := abi.Events["Transfer"]
+	if hex.EncodeToString(event.ID()) != "ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef" {
+		t.Errorf("Transfer ID = %x", event.ID())
+	}
+
+	if len(event.Inputs.NonIndexed()) != 1 {
+		t.Errorf("Transfer has %d non-indexed inputs, want 1", len(event.Inputs.NonIndexed()))
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		types  []string
+		values []interface{}
+		// decoded is the %v form of the decoded values.
+		decoded string
+		data    string
+	}{
+		{
+			// The example of the Solidity ABI specification for
+			// f(uint256,uint32[],bytes10,bytes).
+			name:    "dynamic",
+			types:   []string{"uint256", "uint32[]", "bytes10", "bytes"},
+			values:  []interface{}{0x123, []int{0x456, 0x789}, []byte("1234567890"), []byte("Hello, world!")},
+			decoded: "[291 [1110 1929] [49 50 51 52 53 54 55 56 57 48] [72 101 108 108 111 44 32 119 111 114 108 100 33]]",
+			data: `
+				0000000000000000000000000000000000000000000000000000000000000123
+				0000000000000000000000000000000000000000000000000000000000000080
+				3132333435363738393000000000000000000000000000000000000000000000
+				00000000000000000000000000000000000000000000000000000000000000e0
+				0000000000000000000000000000000000000000000000000000000000000002
+				0000000000000000000000000000000000000000000000000000000000000456
+				0000000000000000000000000000000000000000000000000000000000000789
+				000000000000000000000000000000000000000000000000000000000000000d
+				48656c6c6f2c20776f726c642100000000000000000000000000000000000000`,
+		},
+		{
+			name:    "string and bool",
+			types:   []string{"string", "bool"},
+			values:  []interface{}{"Tether USD", true},
+			decoded: "[Tether USD true]",
+			data: `
+				0000000000000000000000000000000000000000000000000000000000000040
+				0000000000000000000000000000000000000000000000000000000000000001
+				000000000000000000000000000000000000000000000000000000000000000a
+				5465746865722055534400000000000000000000000000000000000000000000`,
+		},
+		{
+			name:    "negative int",
+			types:   []string{"int256", "int8"},
+			values:  []interface{}{-1, -128},
+			decoded: "[-1 -128]",
+			data: `
+				ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff
+				ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80`,
+		},
+		{
+			name:    "fixed array",
+			types:   []string{"uint8[2]", "trcToken"},
+			values:  []interface{}{[2]uint8{1, 2}, "1002000"},
+			decoded: "[[1 2] 1002000]",
+			data: `
+				0000000000000000000000000000000000000000000000000000000000000001
+				0000000000000000000000000000000000000000000000000000000000000002
+				00000000000000000000000000000000000000000000000000000000000f4a10`,
+		},
+		{
+			name:    "function",
+			types:   []string{"function"},
+			values:  []interface{}{mustHex(t, "a614f803b6fd780986a42c78ec9c7f77e6ded13ca9059cbb")},
+			decoded: fmt.Sprint([]interface{}{mustHex(t, "a614f803b6fd780986a42c78ec9c7f77e6ded13ca9059cbb")}),
+			data: `
+				a614f803b6fd780986a42c78ec9c7f77e6ded13ca9059cbb0000000000000000`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			args := mustArguments(t, tt.types...)
+			want := mustHex(t, tt.data)
+
+			data, err := args.Encode(tt.values...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(data, want) {
+				t.Errorf("Encode = %x, want %x", data, want)
+			}
+
+			values, err := args.Decode(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := fmt.Sprint(values); got != tt.decoded {
+				t.Errorf("Decode = %s, want %s", got, tt.decoded)
+			}
+		})
+	}
+}
+
+func TestFunctionSignature(t *testing.T) {
+
+	method := &Method{Name: "call", Inputs: mustArguments(t, "function", "bytes24")}
+
+	if method.Signature() != "call(function,bytes24)" {
+		t.Errorf("Signature = %s, want call(function,bytes24)", method.Signature())
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+
+	tests := []struct {
+		typ   string
+		value interface{}
+	}{
+		{"uint8", 256},
+		{"uint256", -1},
+		{"int8", 128},
+		{"address", "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u"},
+		{"bool", 1},
+		{"string", []byte("x")},
+		{"bytes2", []byte{1, 2, 3}},
+		{"uint8[2]", []int{1}},
+		{"function", make([]byte, 20)},
+	}
+
+	for _, tt := range tests {
+		_, err := mustArguments(t, tt.typ).Encode(tt.value)
+		if err == nil {
+			t.Errorf("Encode(%s, %v) succeeded", tt.typ, tt.value)
+		}
+	}
+}
+
+func TestDecodeShortData(t *testing.T) {
+
+	args := mustArguments(t, "string")
+
+	data := mustHex(t, `
+		0000000000000000000000000000000000000000000000000000000000000020
+		0000000000000000000000000000000000000000000000000000000000000040
+		5465746865722055534400000000000000000000000000000000000000000000`)
+
+	for _, d := range [][]byte{nil, data[:31], data} {
+		_, err := args.Decode(d)
+		if err == nil {
+			t.Errorf("Decode(%x) succeeded", d)
+		}
+	}
+}
+
+func TestNewType(t *testing.T) {
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"uint", "uint256"},
+		{"int", "int256"},
+		{"trcToken", "uint256"},
+		{"bytes32", "bytes32"},
+		{"address[]", "address[]"},
+		{"uint256[2][]", "uint256[2][]"},
+		{"function", "function"},
+	}
+
+	for _, tt := range tests {
+		typ, err := NewType(tt.in, nil)
+		if err != nil || typ.String() != tt.want {
+			t.Errorf("NewType(%q) = %s, %v, want %s", tt.in, typ, err, tt.want)
+		}
+	}
+
+	for _, in := range []string{"uint7", "uint264", "bytes0", "bytes33", "tuple", "uint[0]", "map"} {
+		_, err := NewType(in, nil)
+		if err == nil {
+			t.Errorf("NewType(%q) succeeded", in)
+		}
+	}
+}
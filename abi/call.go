@@ -0,0 +1,88 @@
+package abi
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/TheTeaParty/trongrid"
+)
+
+var ErrReverted = errors.New("abi: call reverted")
+
+// revertReason is Error(string), which require and revert with a message
+// return.
+var revertReason = Method{Name: "Error", Inputs: Arguments{{Type: Type{Kind: KindString}}}}
+
+// ConstantCall returns the request calling the method of contract with
+// args from caller.
+func (m *Method) ConstantCall(caller, contract trongrid.Address, args ...interface{}) (*trongrid.TriggerConstantContractRequest, error) {
+
+	parameter, err := m.Inputs.Encode(args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", m.Name, err)
+	}
+
+	return &trongrid.TriggerConstantContractRequest{
+		OwnerAddress:     caller,
+		ContractAddress:  contract,
+		FunctionSelector: m.Signature(),
+		Parameter:        hex.EncodeToString(parameter),
+	}, nil
+}
+
+// Call calls the method of contract with TriggerConstantContract and
+// decodes its outputs.
+func (m *Method) Call(ctx context.Context, client trongrid.Client, caller, contract trongrid.Address, args ...interface{}) ([]interface{}, error) {
+
+	req, err := m.ConstantCall(caller, contract, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.TriggerConstantContract(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := m.Result(response)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.Unpack(result)
+}
+
+// Result returns the return data of a constant call of the method. A
+// reverted call returns an error wrapping ErrReverted with the revert
+// message, if any.
+func (m *Method) Result(response *trongrid.TriggerConstantContractResponse) ([]byte, error) {
+
+	if len(response.ConstantResult) == 0 {
+		if response.Reverted() {
+			return nil, fmt.Errorf("%s: %w", m.Name, ErrReverted)
+		}
+
+		return nil, fmt.Errorf("%w: %s returned nothing", trongrid.ErrNoDataInResponse, m.Signature())
+	}
+
+	result, err := hex.DecodeString(response.ConstantResult[0])
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid result: %w", m.Name, err)
+	}
+
+	if response.Reverted() {
+		if bytes.HasPrefix(result, revertReason.ID()) {
+			values, err := revertReason.Inputs.Decode(result[4:])
+			if err == nil {
+				return nil, fmt.Errorf("%s: %w: %s", m.Name, ErrReverted, values[0])
+			}
+		}
+
+		return nil, fmt.Errorf("%s: %w", m.Name, ErrReverted)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,172 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/TheTeaParty/trongrid"
+)
+
+var ErrShortData = errors.New("abi: data too short")
+
+// Decode decodes data encoded as a tuple of the arguments.
+func (a Arguments) Decode(data []byte) ([]interface{}, error) {
+
+	types := make([]Type, len(a))
+	for i, argument := range a {
+		types[i] = argument.Type
+	}
+
+	return decodeTuple(types, data)
+}
+
+// DecodeMap is like Decode but returns the values by argument name.
+func (a Arguments) DecodeMap(data []byte) (map[string]interface{}, error) {
+
+	values, err := a.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]interface{}, len(values))
+	for i, argument := range a {
+		m[argument.Name] = values[i]
+	}
+
+	return m, nil
+}
+
+func decodeTuple(types []Type, data []byte) ([]interface{}, error) {
+
+	values := make([]interface{}, len(types))
+	offset := 0
+
+	for i, t := range types {
+		var err error
+
+		if t.dynamic() {
+			var start int
+			start, err = readLength(data, offset)
+			if err != nil {
+				return nil, err
+			}
+
+			if start > len(data) {
+				return nil, ErrShortData
+			}
+
+			values[i], err = decode(t, data[start:])
+		} else {
+			if offset+t.headSize() > len(data) {
+				return nil, ErrShortData
+			}
+
+			values[i], err = decode(t, data[offset:])
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %w", i, err)
+		}
+
+		offset += t.headSize()
+	}
+
+	return values, nil
+}
+
+// decode decodes a value of type t at the start of data.
+func decode(t Type, data []byte) (interface{}, error) {
+
+	switch t.Kind {
+	case KindUint, KindInt, KindAddress, KindBool, KindFixedBytes, KindFunction:
+		if len(data) < 32 {
+			return nil, ErrShortData
+		}
+	}
+
+	switch t.Kind {
+	case KindUint:
+		return new(big.Int).SetBytes(data[:32]), nil
+
+	case KindInt:
+		n := new(big.Int).SetBytes(data[:32])
+		if data[0]&0x80 != 0 {
+			n.Sub(n, word)
+		}
+		return n, nil
+
+	case KindAddress:
+		return trongrid.AddressFromBytes(data[12:32])
+
+	case KindBool:
+		return data[31] == 1, nil
+
+	case KindFixedBytes, KindFunction:
+		return append([]byte(nil), data[:t.Size]...), nil
+
+	case KindString, KindBytes:
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if 32+length > len(data) {
+			return nil, ErrShortData
+		}
+
+		b := append([]byte(nil), data[32:32+length]...)
+		if t.Kind == KindString {
+			return string(b), nil
+		}
+		return b, nil
+
+	case KindSlice, KindArray:
+		length := t.Length
+		if t.Kind == KindSlice {
+			var err error
+			length, err = readLength(data, 0)
+			if err != nil {
+				return nil, err
+			}
+
+			data = data[32:]
+		}
+
+		if length > len(data) {
+			return nil, ErrShortData
+		}
+
+		types := make([]Type, length)
+		for i := range types {
+			types[i] = *t.Elem
+		}
+
+		return decodeTuple(types, data)
+
+	case KindTuple:
+		types := make([]Type, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type
+		}
+
+		return decodeTuple(types, data)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// readLength reads the word at offset as an offset or length.
+func readLength(data []byte, offset int) (int, error) {
+
+	if offset+32 > len(data) {
+		return 0, ErrShortData
+	}
+
+	n := new(big.Int).SetBytes(data[offset : offset+32])
+	if !n.IsInt64() || n.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("abi: offset or length %s out of range", n)
+	}
+
+	return int(n.Int64()), nil
+}
@@ -0,0 +1,315 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/TheTeaParty/trongrid"
+)
+
+var (
+	big1       = big.NewInt(1)
+	word       = new(big.Int).Lsh(big1, 256)
+	uint64Type = reflect.TypeOf(uint64(0))
+)
+
+// Encode encodes values as a tuple of the arguments.
+//
+// Integers accept Go integers, *big.Int, trongrid.Amount and decimal
+// strings. Addresses accept trongrid.Address and strings in any form
+// ParseAddress accepts. Bytes accept []byte and byte arrays, arrays and
+// slices accept any Go slice or array, and tuples accept []interface{} in
+// component order or map[string]interface{} by component name.
+func (a Arguments) Encode(values ...interface{}) ([]byte, error) {
+
+	if len(values) != len(a) {
+		return nil, fmt.Errorf("got %d arguments, want %d", len(values), len(a))
+	}
+
+	types := make([]Type, len(a))
+	for i, argument := range a {
+		types[i] = argument.Type
+	}
+
+	return encodeTuple(types, values)
+}
+
+func encodeTuple(types []Type, values []interface{}) ([]byte, error) {
+
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+
+	for i, t := range types {
+		encoded, err := encode(t, values[i])
+		if err != nil {
+			return nil, fmt.Errorf("argument %d: %w", i, err)
+		}
+
+		if !t.dynamic() {
+			head = append(head, encoded...)
+			continue
+		}
+
+		head = append(head, uintWord(uint64Value(headSize+len(tail)))...)
+		tail = append(tail, encoded...)
+	}
+
+	return append(head, tail...), nil
+}
+
+func encode(t Type, value interface{}) ([]byte, error) {
+
+	switch t.Kind {
+	case KindUint, KindInt:
+		n, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeInt(t, n)
+
+	case KindAddress:
+		address, err := toAddress(value)
+		if err != nil {
+			return nil, err
+		}
+
+		w := make([]byte, 32)
+		evm := address.EVM()
+		copy(w[12:], evm[:])
+		return w, nil
+
+	case KindBool:
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("cannot encode %T as bool", value)
+		}
+
+		w := make([]byte, 32)
+		if b {
+			w[31] = 1
+		}
+		return w, nil
+
+	case KindString:
+		s, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot encode %T as string", value)
+		}
+
+		return encodeBytes([]byte(s)), nil
+
+	case KindBytes:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeBytes(b), nil
+
+	case KindFixedBytes, KindFunction:
+		b, err := toBytes(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(b) != t.Size {
+			return nil, fmt.Errorf("got %d bytes for %s", len(b), t)
+		}
+
+		return padRight(b), nil
+
+	case KindSlice, KindArray:
+		elems, err := toSlice(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind == KindArray && len(elems) != t.Length {
+			return nil, fmt.Errorf("got %d elements for %s", len(elems), t)
+		}
+
+		types := make([]Type, len(elems))
+		for i := range types {
+			types[i] = *t.Elem
+		}
+
+		encoded, err := encodeTuple(types, elems)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.Kind == KindSlice {
+			encoded = append(uintWord(uint64Value(len(elems))), encoded...)
+		}
+
+		return encoded, nil
+
+	case KindTuple:
+		fields, err := toFields(t.Components, value)
+		if err != nil {
+			return nil, err
+		}
+
+		types := make([]Type, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type
+		}
+
+		return encodeTuple(types, fields)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", t)
+}
+
+// encodeInt encodes n as a word, in two's complement if negative, after
+// checking it fits the type.
+func encodeInt(t Type, n *big.Int) ([]byte, error) {
+
+	lo, hi := new(big.Int), new(big.Int).Lsh(big1, uint(t.Size))
+	if t.Kind == KindInt {
+		hi.Rsh(hi, 1)
+		lo.Neg(hi)
+	}
+
+	if n.Cmp(lo) < 0 || n.Cmp(hi) >= 0 {
+		return nil, fmt.Errorf("%s out of %s range", n, t)
+	}
+
+	if n.Sign() < 0 {
+		n = new(big.Int).Add(n, word)
+	}
+
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+func encodeBytes(b []byte) []byte {
+	return append(uintWord(uint64Value(len(b))), padRight(b)...)
+}
+
+func padRight(b []byte) []byte {
+	padded := make([]byte, (len(b)+31)/32*32)
+	copy(padded, b)
+	return padded
+}
+
+func uintWord(n *big.Int) []byte {
+	return n.FillBytes(make([]byte, 32))
+}
+
+func uint64Value(n int) *big.Int {
+	return new(big.Int).SetUint64(uint64(n))
+}
+
+func toBigInt(value interface{}) (*big.Int, error) {
+
+	switch v := value.(type) {
+	case *big.Int:
+		if v == nil {
+			return nil, fmt.Errorf("nil *big.Int")
+		}
+		return v, nil
+	case big.Int:
+		return &v, nil
+	case trongrid.Amount:
+		return v.Int(), nil
+	case string:
+		n, ok := new(big.Int).SetString(v, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer %q", v)
+		}
+		return n, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Int).SetUint64(rv.Convert(uint64Type).Uint()), nil
+	}
+
+	return nil, fmt.Errorf("cannot encode %T as integer", value)
+}
+
+func toAddress(value interface{}) (trongrid.Address, error) {
+
+	switch v := value.(type) {
+	case trongrid.Address:
+		return v, nil
+	case *trongrid.Address:
+		return *v, nil
+	case string:
+		return trongrid.ParseAddress(v)
+	case [20]byte:
+		return trongrid.AddressFromBytes(v[:])
+	case []byte:
+		return trongrid.AddressFromBytes(v)
+	}
+
+	return trongrid.Address{}, fmt.Errorf("cannot encode %T as address", value)
+}
+
+func toBytes(value interface{}) ([]byte, error) {
+
+	if b, ok := value.([]byte); ok {
+		return b, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		b := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(b), rv)
+		return b, nil
+	}
+
+	return nil, fmt.Errorf("cannot encode %T as bytes", value)
+}
+
+func toSlice(value interface{}) ([]interface{}, error) {
+
+	if elems, ok := value.([]interface{}); ok {
+		return elems, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("cannot encode %T as array", value)
+	}
+
+	elems := make([]interface{}, rv.Len())
+	for i := range elems {
+		elems[i] = rv.Index(i).Interface()
+	}
+
+	return elems, nil
+}
+
+func toFields(components Arguments, value interface{}) ([]interface{}, error) {
+
+	switch v := value.(type) {
+	case []interface{}:
+		if len(v) != len(components) {
+			return nil, fmt.Errorf("got %d fields, want %d", len(v), len(components))
+		}
+		return v, nil
+
+	case map[string]interface{}:
+		fields := make([]interface{}, len(components))
+		for i, c := range components {
+			field, ok := v[c.Name]
+			if !ok {
+				return nil, fmt.Errorf("missing field %q", c.Name)
+			}
+			fields[i] = field
+		}
+		return fields, nil
+	}
+
+	return nil, fmt.Errorf("cannot encode %T as tuple", value)
+}
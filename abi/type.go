@@ -0,0 +1,188 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of an ABI type.
+type Kind int
+
+const (
+	KindUint Kind = iota
+	KindInt
+	KindAddress
+	KindBool
+	KindString
+	KindBytes
+	KindFixedBytes
+	KindSlice
+	KindArray
+	KindTuple
+	// KindFunction is an external function reference: a 20-byte address
+	// followed by a 4-byte selector, encoded as bytes24.
+	KindFunction
+)
+
+// Type is a Solidity ABI type.
+type Type struct {
+	Kind Kind
+	// Size is the bit size of integers and the byte size of fixed bytes
+	// and functions.
+	Size int
+	// Length is the length of fixed arrays.
+	Length int
+	// Elem is the element type of arrays and slices.
+	Elem *Type
+	// Components are the fields of tuples.
+	Components Arguments
+}
+
+// NewType parses a type such as "uint256", "address[]" or "tuple[2]".
+// Tuple types take their fields from components. trcToken, the TRC10
+// token id type of TRON, is an alias of uint256.
+func NewType(s string, components Arguments) (Type, error) {
+
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if strings.HasSuffix(s, "]") {
+		i := strings.LastIndex(s, "[")
+		if i < 0 {
+			return Type{}, fmt.Errorf("invalid type %q", s)
+		}
+
+		elem, err := NewType(s[:i], components)
+		if err != nil {
+			return Type{}, err
+		}
+
+		size := s[i+1 : len(s)-1]
+		if size == "" {
+			return Type{Kind: KindSlice, Elem: &elem}, nil
+		}
+
+		length, err := strconv.Atoi(size)
+		if err != nil || length <= 0 {
+			return Type{}, fmt.Errorf("invalid array length in %q", s)
+		}
+
+		return Type{Kind: KindArray, Length: length, Elem: &elem}, nil
+	}
+
+	switch {
+	case s == "address":
+		return Type{Kind: KindAddress, Size: 160}, nil
+	case s == "bool":
+		return Type{Kind: KindBool}, nil
+	case s == "string":
+		return Type{Kind: KindString}, nil
+	case s == "bytes":
+		return Type{Kind: KindBytes}, nil
+	case s == "function":
+		return Type{Kind: KindFunction, Size: 24}, nil
+	case s == "trctoken":
+		return Type{Kind: KindUint, Size: 256}, nil
+	case s == "tuple":
+		if len(components) == 0 {
+			return Type{}, fmt.Errorf("tuple without components")
+		}
+		return Type{Kind: KindTuple, Components: components}, nil
+	case strings.HasPrefix(s, "uint"):
+		return intType(KindUint, s, s[len("uint"):])
+	case strings.HasPrefix(s, "int"):
+		return intType(KindInt, s, s[len("int"):])
+	case strings.HasPrefix(s, "bytes"):
+		size, err := strconv.Atoi(s[len("bytes"):])
+		if err != nil || size < 1 || size > 32 {
+			return Type{}, fmt.Errorf("invalid type %q", s)
+		}
+		return Type{Kind: KindFixedBytes, Size: size}, nil
+	}
+
+	return Type{}, fmt.Errorf("unsupported type %q", s)
+}
+
+func intType(kind Kind, s, bits string) (Type, error) {
+
+	if bits == "" {
+		return Type{Kind: kind, Size: 256}, nil
+	}
+
+	size, err := strconv.Atoi(bits)
+	if err != nil || size < 8 || size > 256 || size%8 != 0 {
+		return Type{}, fmt.Errorf("invalid type %q", s)
+	}
+
+	return Type{Kind: kind, Size: size}, nil
+}
+
+// String returns the canonical form of the type used in signatures,
+// e.g. "(address,uint256)[]" for a slice of tuples.
+func (t Type) String() string {
+	switch t.Kind {
+	case KindUint:
+		return fmt.Sprintf("uint%d", t.Size)
+	case KindInt:
+		return fmt.Sprintf("int%d", t.Size)
+	case KindAddress:
+		return "address"
+	case KindBool:
+		return "bool"
+	case KindString:
+		return "string"
+	case KindBytes:
+		return "bytes"
+	case KindFixedBytes:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case KindFunction:
+		return "function"
+	case KindSlice:
+		return t.Elem.String() + "[]"
+	case KindArray:
+		return fmt.Sprintf("%s[%d]", t.Elem.String(), t.Length)
+	case KindTuple:
+		return "(" + t.Components.types() + ")"
+	}
+
+	return "invalid"
+}
+
+// dynamic reports whether values of the type are encoded out of line.
+func (t Type) dynamic() bool {
+	switch t.Kind {
+	case KindString, KindBytes, KindSlice:
+		return true
+	case KindArray:
+		return t.Elem.dynamic()
+	case KindTuple:
+		for _, c := range t.Components {
+			if c.Type.dynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// headSize returns the size of the type in the head of a tuple.
+func (t Type) headSize() int {
+
+	if t.dynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case KindArray:
+		return t.Length * t.Elem.headSize()
+	case KindTuple:
+		size := 0
+		for _, c := range t.Components {
+			size += c.Type.headSize()
+		}
+		return size
+	}
+
+	return 32
+}
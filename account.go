@@ -1,38 +1,23 @@
 package trongrid
 
+import "encoding/hex"
+
 type Account struct {
-	Address               string `json:"address"`
-	Balance               int64  `json:"balance"`
-	CreateTime            int64  `json:"create_time"`
-	LatestOprationTime    int64  `json:"latest_opration_time"`
-	LatestConsumeFreeTime int64  `json:"latest_consume_free_time"`
-	NetWindowSize         int    `json:"net_window_size"`
-	NetWindowOptimized    bool   `json:"net_window_optimized"`
+	Address               Address `json:"address"`
+	Balance               Sun     `json:"balance"`
+	CreateTime            int64   `json:"create_time"`
+	LatestOprationTime    int64   `json:"latest_opration_time"`
+	LatestConsumeFreeTime int64   `json:"latest_consume_free_time"`
+	NetWindowSize         int     `json:"net_window_size"`
+	NetWindowOptimized    bool    `json:"net_window_optimized"`
 	AccountResource       struct {
 		LatestConsumeTimeForEnergy int64 `json:"latest_consume_time_for_energy"`
 		EnergyWindowSize           int   `json:"energy_window_size"`
 		EnergyWindowOptimized      bool  `json:"energy_window_optimized"`
 	} `json:"account_resource"`
-	OwnerPermission struct {
-		PermissionName string `json:"permission_name"`
-		Threshold      int    `json:"threshold"`
-		Keys           []struct {
-			Address string `json:"address"`
-			Weight  int    `json:"weight"`
-		} `json:"keys"`
-	} `json:"owner_permission"`
-	ActivePermission []struct {
-		Type           string `json:"type"`
-		Id             int    `json:"id"`
-		PermissionName string `json:"permission_name"`
-		Threshold      int    `json:"threshold"`
-		Operations     string `json:"operations"`
-		Keys           []struct {
-			Address string `json:"address"`
-			Weight  int    `json:"weight"`
-		} `json:"keys"`
-	} `json:"active_permission"`
-	FrozenV2 []struct {
+	OwnerPermission  Permission   `json:"owner_permission"`
+	ActivePermission []Permission `json:"active_permission"`
+	FrozenV2         []struct {
 		Type string `json:"type,omitempty"`
 	} `json:"frozenV2"`
 	AssetV2 []struct {
@@ -44,4 +29,51 @@ type Account struct {
 		Value int    `json:"value"`
 	} `json:"free_asset_net_usageV2"`
 	AssetOptimized bool `json:"asset_optimized"`
+	// Consistency is the confirmation level the account was read at.
+	Consistency Consistency `json:"-"`
+}
+
+// Permission is an owner or active permission of an account. A
+// transaction signed for the permission is valid once the weights of its
+// signers reach Threshold.
+type Permission struct {
+	Type           string          `json:"type"`
+	Id             int             `json:"id"`
+	PermissionName string          `json:"permission_name"`
+	Threshold      int             `json:"threshold"`
+	Operations     string          `json:"operations"`
+	Keys           []PermissionKey `json:"keys"`
+}
+
+type PermissionKey struct {
+	Address Address `json:"address"`
+	Weight  int     `json:"weight"`
+}
+
+// Weight returns the weight of address in the permission, or 0 if it is
+// not one of its keys.
+func (p *Permission) Weight(address Address) int {
+	for _, key := range p.Keys {
+		if key.Address == address {
+			return key.Weight
+		}
+	}
+
+	return 0
+}
+
+// Allows reports whether the permission may sign contracts of the given
+// type. Owner permissions, which carry no operations, allow every type.
+func (p *Permission) Allows(contractType int32) bool {
+
+	if p.Operations == "" {
+		return true
+	}
+
+	operations, err := hex.DecodeString(p.Operations)
+	if err != nil || contractType < 0 || int(contractType/8) >= len(operations) {
+		return false
+	}
+
+	return operations[contractType/8]&(1<<(contractType%8)) != 0
 }
@@ -1,7 +1,7 @@
 package trongrid
 
 type AccountBalance struct {
-	Balance         uint64          `json:"balance"`
+	Balance         Sun             `json:"balance"`
 	BlockIdentifier BlockIdentifier `json:"block_identifier"`
 }
 
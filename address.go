@@ -0,0 +1,143 @@
+package trongrid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const (
+	// AddressLength is the length of a TRON address in bytes, including
+	// the 0x41 prefix.
+	AddressLength = 21
+	// AddressPrefix is the first byte of every mainnet and testnet TRON
+	// address.
+	AddressPrefix byte = 0x41
+)
+
+// Address is a TRON account or contract address. The zero value is the
+// empty address and encodes as an empty string.
+//
+// Addresses are parsed from and encoded to JSON in either Base58Check
+// (T...) or hex (41...) form, matching the "visible" flag of the API.
+type Address [AddressLength]byte
+
+// ParseAddress parses a Base58Check address, a 41-prefixed hex address or
+// a 20-byte EVM-style hex address with or without the 0x prefix.
+func ParseAddress(s string) (Address, error) {
+
+	var a Address
+
+	switch {
+	case s == "":
+		return a, fmt.Errorf("invalid address: empty")
+	case len(s) == 2*AddressLength && strings.HasPrefix(s, "41"):
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return a, fmt.Errorf("invalid address %q: %w", s, err)
+		}
+		return AddressFromBytes(b)
+	case len(s) == 42 && (strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X")):
+		s = s[2:]
+		fallthrough
+	case len(s) == 40:
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return a, fmt.Errorf("invalid address %q: %w", s, err)
+		}
+		return AddressFromBytes(b)
+	}
+
+	b, err := base58CheckDecode(s)
+	if err != nil {
+		return a, fmt.Errorf("invalid address %q: %w", s, err)
+	}
+
+	return AddressFromBytes(b)
+}
+
+// MustParseAddress is like ParseAddress but panics on error. It is meant
+// for constants such as well-known contract addresses.
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// AddressFromBytes returns the address of a 21-byte 0x41-prefixed or a
+// 20-byte EVM-style address.
+func AddressFromBytes(b []byte) (Address, error) {
+
+	var a Address
+
+	switch {
+	case len(b) == AddressLength && b[0] == AddressPrefix:
+		copy(a[:], b)
+	case len(b) == AddressLength-1:
+		a[0] = AddressPrefix
+		copy(a[1:], b)
+	default:
+		return a, fmt.Errorf("invalid address bytes %x", b)
+	}
+
+	return a, nil
+}
+
+// IsZero reports whether a is the empty address.
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// String returns the Base58Check form of the address, e.g. T...
+func (a Address) String() string {
+	if a.IsZero() {
+		return ""
+	}
+
+	return base58CheckEncode(a[:])
+}
+
+// Hex returns the 41-prefixed hex form of the address.
+func (a Address) Hex() string {
+	if a.IsZero() {
+		return ""
+	}
+
+	return hex.EncodeToString(a[:])
+}
+
+// EVM returns the 20-byte form of the address used by the TVM and ABI
+// encoding.
+func (a Address) EVM() [20]byte {
+	var b [20]byte
+	copy(b[:], a[1:])
+	return b
+}
+
+// Bytes returns the 21-byte form of the address.
+func (a Address) Bytes() []byte {
+	return a[:]
+}
+
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = Address{}
+		return nil
+	}
+
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+
+	return nil
+}
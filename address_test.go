@@ -0,0 +1,123 @@
+package trongrid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+
+	const (
+		base58 = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+		hex41  = "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	)
+
+	tests := []struct {
+		in      string
+		wantErr bool
+	}{
+		{in: base58},
+		{in: hex41},
+		{in: "a614f803b6fd780986a42c78ec9c7f77e6ded13c"},
+		{in: "0xa614f803b6fd780986a42c78ec9c7f77e6ded13c"},
+		{in: "0XA614F803B6FD780986A42C78EC9C7F77E6DED13C"},
+		{in: "", wantErr: true},
+		// Last character changed, so the checksum no longer matches.
+		{in: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u", wantErr: true},
+		// 0 is not a Base58 digit.
+		{in: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj60", wantErr: true},
+		{in: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj", wantErr: true},
+		{in: "42a614f803b6fd780986a42c78ec9c7f77e6ded13c", wantErr: true},
+		{in: "41a614f803b6fd780986a42c78ec9c7f77e6ded1zz", wantErr: true},
+		{in: "0xa614f803b6fd780986a42c78ec9c7f77e6ded1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAddress(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAddress(%q) = %s, want error", tt.in, a)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseAddress(%q): %v", tt.in, err)
+			continue
+		}
+
+		if a.String() != base58 {
+			t.Errorf("ParseAddress(%q).String() = %s, want %s", tt.in, a, base58)
+		}
+
+		if a.Hex() != hex41 {
+			t.Errorf("ParseAddress(%q).Hex() = %s, want %s", tt.in, a.Hex(), hex41)
+		}
+	}
+}
+
+func TestAddressFromBytes(t *testing.T) {
+
+	a := MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	evm := a.EVM()
+
+	tests := []struct {
+		in      []byte
+		wantErr bool
+	}{
+		{in: a.Bytes()},
+		{in: evm[:]},
+		{in: nil, wantErr: true},
+		{in: append([]byte{0x42}, evm[:]...), wantErr: true},
+		{in: evm[1:], wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := AddressFromBytes(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("AddressFromBytes(%x) = %s, want error", tt.in, got)
+			}
+			continue
+		}
+
+		if err != nil || got != a {
+			t.Errorf("AddressFromBytes(%x) = %s, %v, want %s", tt.in, got, err, a)
+		}
+	}
+}
+
+func TestAddressJSON(t *testing.T) {
+
+	type wrapper struct {
+		Address Address `json:"address"`
+	}
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`{"address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"}`, `{"address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"}`},
+		{`{"address":"41a614f803b6fd780986a42c78ec9c7f77e6ded13c"}`, `{"address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"}`},
+		{`{"address":""}`, `{"address":""}`},
+	}
+
+	for _, tt := range tests {
+		var w wrapper
+		err := json.Unmarshal([]byte(tt.in), &w)
+		if err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+
+		b, err := json.Marshal(w)
+		if err != nil || string(b) != tt.want {
+			t.Errorf("Marshal(Unmarshal(%s)) = %s, %v, want %s", tt.in, b, err, tt.want)
+		}
+	}
+
+	var w wrapper
+	if json.Unmarshal([]byte(`{"address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u"}`), &w) == nil {
+		t.Error("Unmarshal accepted an address with a bad checksum")
+	}
+}
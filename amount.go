@@ -0,0 +1,218 @@
+package trongrid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// TRXDecimals is the number of decimals of TRX.
+	TRXDecimals = 6
+	// SunPerTRX is the number of SUN in one TRX.
+	SunPerTRX Sun = 1_000_000
+	// MaxDecimals is the largest number of decimals a token can use: a
+	// uint256 has at most 78 digits.
+	MaxDecimals = 77
+)
+
+// Sun is an amount of TRX in SUN, its smallest unit.
+type Sun int64
+
+// ParseTRX parses a decimal TRX amount such as "12.5" into SUN. More than
+// six decimals are rejected rather than rounded.
+func ParseTRX(s string) (Sun, error) {
+
+	a, err := ParseAmount(s, TRXDecimals)
+	if err != nil {
+		return 0, err
+	}
+
+	if !a.Int().IsInt64() {
+		return 0, fmt.Errorf("TRX amount %q out of range", s)
+	}
+
+	return Sun(a.Int().Int64()), nil
+}
+
+// TRX returns the amount as a decimal TRX string, e.g. "12.5".
+func (s Sun) TRX() string {
+	return s.Amount().String()
+}
+
+// Amount returns the amount as an Amount with TRX decimals.
+func (s Sun) Amount() Amount {
+	return NewAmount(big.NewInt(int64(s)), TRXDecimals)
+}
+
+// Amount is an exact token amount: an integer number of base units and
+// the number of decimals of the token. The zero value is zero with no
+// decimals.
+//
+// Amounts are encoded to JSON as a string of base units, the format used
+// by TronGrid, so decimals have to be restored after decoding.
+type Amount struct {
+	value    *big.Int
+	decimals int
+}
+
+// NewAmount returns an amount of value base units. value is copied.
+func NewAmount(value *big.Int, decimals int) Amount {
+	return Amount{value: new(big.Int).Set(value), decimals: decimals}
+}
+
+// ParseAmount parses a decimal string such as "1.25" for a token with the
+// given decimals. More fractional digits than decimals are rejected.
+func ParseAmount(s string, decimals int) (Amount, error) {
+
+	if decimals < 0 || decimals > MaxDecimals {
+		return Amount{}, fmt.Errorf("invalid decimals %d", decimals)
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+
+	if len(frac) > decimals {
+		return Amount{}, fmt.Errorf("amount %q has more than %d decimals", s, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+
+	value, ok := new(big.Int).SetString(digits, 10)
+	if !ok || whole == "" || whole == "-" || strings.HasPrefix(frac, "-") || strings.HasPrefix(frac, "+") {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	return Amount{value: value, decimals: decimals}, nil
+}
+
+// ParseAmountUnits parses an integer number of base units, the form used
+// by the "value" field of TRC20 transfers.
+func ParseAmountUnits(s string, decimals int) (Amount, error) {
+
+	if decimals < 0 || decimals > MaxDecimals {
+		return Amount{}, fmt.Errorf("invalid decimals %d", decimals)
+	}
+
+	value, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	return Amount{value: value, decimals: decimals}, nil
+}
+
+// Int returns a copy of the amount in base units.
+func (a Amount) Int() *big.Int {
+	if a.value == nil {
+		return new(big.Int)
+	}
+
+	return new(big.Int).Set(a.value)
+}
+
+// Decimals returns the number of decimals of the token.
+func (a Amount) Decimals() int {
+	return a.decimals
+}
+
+// WithDecimals returns the same number of base units with other decimals.
+func (a Amount) WithDecimals(decimals int) Amount {
+	return Amount{value: a.Int(), decimals: decimals}
+}
+
+// IsZero reports whether the amount is zero.
+func (a Amount) IsZero() bool {
+	return a.value == nil || a.value.Sign() == 0
+}
+
+// Cmp compares a and b, which may have different decimals, and returns
+// -1, 0 or +1.
+func (a Amount) Cmp(b Amount) int {
+	x, y := scaleAmounts(a, b)
+	return x.Cmp(y)
+}
+
+// Add returns a + b, with the larger of both decimals.
+func (a Amount) Add(b Amount) Amount {
+	x, y := scaleAmounts(a, b)
+	return Amount{value: x.Add(x, y), decimals: max(a.decimals, b.decimals)}
+}
+
+// Sub returns a - b, with the larger of both decimals.
+func (a Amount) Sub(b Amount) Amount {
+	x, y := scaleAmounts(a, b)
+	return Amount{value: x.Sub(x, y), decimals: max(a.decimals, b.decimals)}
+}
+
+// scaleAmounts returns the base units of a and b scaled to the larger of
+// their decimals.
+func scaleAmounts(a, b Amount) (*big.Int, *big.Int) {
+	x, y := a.Int(), b.Int()
+
+	if a.decimals < b.decimals {
+		x.Mul(x, pow10(b.decimals-a.decimals))
+	} else if b.decimals < a.decimals {
+		y.Mul(y, pow10(a.decimals-b.decimals))
+	}
+
+	return x, y
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// String returns the amount as a decimal string without trailing zeros,
+// e.g. "1.25".
+func (a Amount) String() string {
+
+	value := a.Int()
+
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+		value.Neg(value)
+	}
+
+	digits := value.String()
+	if a.decimals <= 0 {
+		return sign + digits
+	}
+
+	if len(digits) <= a.decimals {
+		digits = strings.Repeat("0", a.decimals-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-a.decimals], strings.TrimRight(digits[len(digits)-a.decimals:], "0")
+	if frac == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + frac
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.Int().String())
+}
+
+// UnmarshalJSON accepts a number of base units as a JSON string or number.
+// The decimals of the receiver are kept.
+func (a *Amount) UnmarshalJSON(b []byte) error {
+
+	b = bytes.Trim(b, `"`)
+	if len(b) == 0 || string(b) == "null" {
+		a.value = new(big.Int)
+		return nil
+	}
+
+	value, ok := new(big.Int).SetString(string(b), 10)
+	if !ok {
+		return fmt.Errorf("invalid amount %s", b)
+	}
+
+	a.value = value
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package trongrid
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestParseAmount(t *testing.T) {
+
+	tests := []struct {
+		in       string
+		decimals int
+		units    string
+		str      string
+		wantErr  bool
+	}{
+		{in: "1.25", decimals: 6, units: "1250000", str: "1.25"},
+		{in: "0.000001", decimals: 6, units: "1", str: "0.000001"},
+		{in: "100", decimals: 0, units: "100", str: "100"},
+		{in: "-1.5", decimals: 6, units: "-1500000", str: "-1.5"},
+		{in: "0", decimals: 18, units: "0", str: "0"},
+		{in: "1.100", decimals: 6, units: "1100000", str: "1.1"},
+		{in: "115792089237316195423570985008687907853269984665640564039457.584007913129639935", decimals: 18, units: "115792089237316195423570985008687907853269984665640564039457584007913129639935", str: "115792089237316195423570985008687907853269984665640564039457.584007913129639935"},
+		{in: "1", decimals: MaxDecimals, units: "1" + strings.Repeat("0", MaxDecimals), str: "1"},
+		{in: "1.1234567", decimals: 6, wantErr: true},
+		{in: "1.5", decimals: 0, wantErr: true},
+		{in: ".5", decimals: 6, wantErr: true},
+		{in: "-", decimals: 6, wantErr: true},
+		{in: "", decimals: 6, wantErr: true},
+		{in: "abc", decimals: 6, wantErr: true},
+		{in: "1.-5", decimals: 6, wantErr: true},
+		{in: "1.+5", decimals: 6, wantErr: true},
+		{in: "1e6", decimals: 6, wantErr: true},
+		{in: "1", decimals: -1, wantErr: true},
+		{in: "1", decimals: MaxDecimals + 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		a, err := ParseAmount(tt.in, tt.decimals)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseAmount(%q, %d) = %s, want error", tt.in, tt.decimals, a)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("ParseAmount(%q, %d): %v", tt.in, tt.decimals, err)
+			continue
+		}
+
+		if a.Int().String() != tt.units {
+			t.Errorf("ParseAmount(%q, %d) = %s units, want %s", tt.in, tt.decimals, a.Int(), tt.units)
+		}
+
+		if a.String() != tt.str {
+			t.Errorf("ParseAmount(%q, %d).String() = %s, want %s", tt.in, tt.decimals, a, tt.str)
+		}
+	}
+}
+
+func TestAmountString(t *testing.T) {
+
+	tests := []struct {
+		amount Amount
+		want   string
+	}{
+		{Amount{}, "0"},
+		{NewAmount(big.NewInt(5), 18), "0.000000000000000005"},
+		{NewAmount(big.NewInt(-5), 2), "-0.05"},
+		{NewAmount(big.NewInt(1000), 3), "1"},
+		{NewAmount(big.NewInt(1001), 3), "1.001"},
+		{NewAmount(big.NewInt(-123), 0), "-123"},
+		{Sun(1_500_000).Amount(), "1.5"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.amount.String(); got != tt.want {
+			t.Errorf("String() = %s, want %s", got, tt.want)
+		}
+	}
+}
+
+func TestParseTRX(t *testing.T) {
+
+	tests := []struct {
+		in      string
+		want    Sun
+		wantErr bool
+	}{
+		{in: "12.5", want: 12_500_000},
+		{in: "0.000001", want: 1},
+		{in: "9223372036854.775807", want: 1<<63 - 1},
+		{in: "9223372036854.775808", wantErr: true},
+		{in: "0.0000001", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTRX(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseTRX(%q) = %d, want error", tt.in, got)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("ParseTRX(%q) = %d, %v, want %d", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseAmountUnits(t *testing.T) {
+
+	a, err := ParseAmountUnits("1250000", 6)
+	if err != nil || a.String() != "1.25" {
+		t.Errorf("ParseAmountUnits = %s, %v, want 1.25", a, err)
+	}
+
+	for _, decimals := range []int{-1, MaxDecimals + 1} {
+		_, err := ParseAmountUnits("1", decimals)
+		if err == nil {
+			t.Errorf("ParseAmountUnits with %d decimals succeeded", decimals)
+		}
+	}
+
+	_, err = ParseAmountUnits("1.5", 6)
+	if err == nil {
+		t.Error("ParseAmountUnits accepted a fraction")
+	}
+}
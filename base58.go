@@ -0,0 +1,112 @@
+package trongrid
+
+import (
+	"crypto/sha256"
+	"errors"
+	"math/big"
+)
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var (
+	errInvalidBase58   = errors.New("invalid base58 string")
+	errInvalidChecksum = errors.New("invalid base58 checksum")
+)
+
+var base58Indexes = func() [256]int {
+	var indexes [256]int
+	for i := range indexes {
+		indexes[i] = -1
+	}
+
+	for i := 0; i < len(base58Alphabet); i++ {
+		indexes[base58Alphabet[i]] = i
+	}
+
+	return indexes
+}()
+
+func base58Encode(b []byte) string {
+
+	n := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+func base58Decode(s string) ([]byte, error) {
+
+	n := new(big.Int)
+	radix := big.NewInt(58)
+
+	for i := 0; i < len(s); i++ {
+		index := base58Indexes[s[i]]
+		if index < 0 {
+			return nil, errInvalidBase58
+		}
+
+		n.Mul(n, radix)
+		n.Add(n, big.NewInt(int64(index)))
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
+
+// base58CheckEncode appends the 4-byte double SHA-256 checksum to payload
+// and encodes the result.
+func base58CheckEncode(payload []byte) string {
+	return base58Encode(append(payload[:len(payload):len(payload)], checksum(payload)...))
+}
+
+// base58CheckDecode decodes s and verifies and strips its checksum.
+func base58CheckDecode(s string) ([]byte, error) {
+
+	b, err := base58Decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) < 5 {
+		return nil, errInvalidBase58
+	}
+
+	payload, sum := b[:len(b)-4], b[len(b)-4:]
+
+	expected := checksum(payload)
+	for i := range sum {
+		if sum[i] != expected[i] {
+			return nil, errInvalidChecksum
+		}
+	}
+
+	return payload, nil
+}
+
+func checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
@@ -1,8 +1,14 @@
 package trongrid
 
+import "sort"
+
 type Block struct {
 	BlockID     string       `json:"blockID"`
 	BlockHeader *BlockHeader `json:"block_header"`
+	// Transactions is empty for blocks read without detail.
+	Transactions []*BlockTransaction `json:"transactions,omitempty"`
+	// Consistency is the confirmation level the block was read at.
+	Consistency Consistency `json:"-"`
 }
 
 type BlockHeader struct {
@@ -11,10 +17,63 @@ type BlockHeader struct {
 }
 
 type BlocHeaderRawData struct {
-	Number         int    `json:"number"`
-	TxTrieRoot     string `json:"txTrieRoot"`
-	WitnessAddress string `json:"witness_address"`
-	ParentHash     string `json:"parentHash"`
-	Version        int    `json:"version"`
-	Timestamp      int64  `json:"timestamp"`
+	Number         int     `json:"number"`
+	TxTrieRoot     string  `json:"txTrieRoot"`
+	WitnessAddress Address `json:"witness_address"`
+	ParentHash     string  `json:"parentHash"`
+	Version        int     `json:"version"`
+	Timestamp      int64   `json:"timestamp"`
+}
+
+// BlockTransaction is a transaction as included in a block. Ret holds the
+// contract result, e.g. "SUCCESS" or "REVERT".
+type BlockTransaction struct {
+	TxID       string             `json:"txID"`
+	Ret        []TransactionRet   `json:"ret"`
+	Signature  []string           `json:"signature"`
+	RawDataHex string             `json:"raw_data_hex"`
+	RawData    TransactionRawData `json:"raw_data"`
+}
+
+// Maximum number of blocks the node returns for one getblockbylimitnext
+// and getblockbylatestnum request.
+const (
+	maxBlocksByRange  = 100
+	maxBlocksByLatest = 99
+)
+
+type blockList struct {
+	Block []*Block `json:"block"`
+}
+
+// Number returns the block number, or -1 if the block has no header.
+func (b *Block) Number() int64 {
+	if b.BlockHeader == nil || b.BlockHeader.RawData == nil {
+		return -1
+	}
+
+	return int64(b.BlockHeader.RawData.Number)
+}
+
+func sortBlocks(blocks []*Block) {
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].Number() < blocks[j].Number()
+	})
+}
+
+// olderRange returns the range below the sorted latest blocks to read so
+// that n blocks are returned in total, as the node returns fewer blocks
+// per getblockbylatestnum request than per range.
+func olderRange(latest []*Block, n int) (start, end uint64, ok bool) {
+
+	if len(latest) == 0 || len(latest) >= n || latest[0].Number() <= 0 {
+		return 0, 0, false
+	}
+
+	end = uint64(latest[0].Number())
+	if missing := uint64(n - len(latest)); missing < end {
+		start = end - missing
+	}
+
+	return start, end, true
 }
@@ -0,0 +1,82 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestGetBlocksByRange(t *testing.T) {
+
+	var (
+		mu       sync.Mutex
+		requests [][2]uint64
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+		var req struct {
+			StartNum uint64 `json:"startNum"`
+			EndNum   uint64 `json:"endNum"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+
+		mu.Lock()
+		requests = append(requests, [2]uint64{req.StartNum, req.EndNum})
+		mu.Unlock()
+
+		var list blockList
+		for n := req.EndNum; n > req.StartNum; n-- {
+			list.Block = append(list.Block, &Block{
+				BlockID:     fmt.Sprintf("%064x", n-1),
+				BlockHeader: &BlockHeader{RawData: &BlocHeaderRawData{Number: int(n - 1)}},
+			})
+		}
+
+		_ = json.NewEncoder(w).Encode(list)
+	}))
+	defer server.Close()
+
+	c := New(WithBaseURL(server.URL))
+
+	tests := []struct {
+		start, end uint64
+		requests   [][2]uint64
+	}{
+		{start: 5, end: 5},
+		{start: 10, end: 15, requests: [][2]uint64{{10, 15}}},
+		{start: 0, end: 150, requests: [][2]uint64{{0, 100}, {100, 150}}},
+	}
+
+	for _, tt := range tests {
+		requests = nil
+
+		blocks, err := c.GetBlocksByRange(context.Background(), tt.start, tt.end)
+		if err != nil {
+			t.Fatalf("GetBlocksByRange(%d, %d): %v", tt.start, tt.end, err)
+		}
+
+		if fmt.Sprint(requests) != fmt.Sprint(tt.requests) {
+			t.Errorf("GetBlocksByRange(%d, %d) requested %v, want %v", tt.start, tt.end, requests, tt.requests)
+		}
+
+		if uint64(len(blocks)) != tt.end-tt.start {
+			t.Fatalf("GetBlocksByRange(%d, %d) returned %d blocks", tt.start, tt.end, len(blocks))
+		}
+
+		for i, block := range blocks {
+			if block.Number() != int64(tt.start)+int64(i) {
+				t.Errorf("block %d is number %d, want %d", i, block.Number(), int64(tt.start)+int64(i))
+			}
+		}
+	}
+
+	_, err := c.GetBlocksByRange(context.Background(), 10, 5)
+	if err == nil {
+		t.Error("GetBlocksByRange accepted end below start")
+	}
+}
@@ -0,0 +1,200 @@
+package trongrid
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// solidityConfirmations is the number of blocks after which a block is
+// considered solidified: two thirds of the 27 super representatives plus
+// one.
+const solidityConfirmations = 19
+
+// contractCacheTTL is how long GetContract results are cached.
+const contractCacheTTL = 10 * time.Minute
+
+// Cache stores encoded responses of chain data. Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+// WithCache caches responses that can never change: blocks below the
+// solidified head and transaction info of solidified transactions. The
+// solidified head is derived from the highest block returned so far, so
+// blocks are only cached once a newer block has been seen. Blocks read by
+// GetBlocksByRange and GetLatestBlocks are cached for GetBlockByNumber.
+//
+// Contracts are only cached for ten minutes, as their owner can change
+// their resource settings or clear their ABI. Cache keys include the
+// network. Head blocks, accounts, balances and contract calls always
+// bypass the cache.
+func WithCache(cache Cache) ClientOption {
+	return func(o *clientOptions) {
+		o.cache = cache
+	}
+}
+
+func (c *client) cacheKey(op string, arg interface{}) string {
+	return fmt.Sprintf("%s/%s/%v", c.options.network, op, arg)
+}
+
+// cacheGet decodes the cached value of key into out.
+func (c *client) cacheGet(key string, out interface{}) bool {
+
+	if c.options.cache == nil {
+		return false
+	}
+
+	b, ok := c.options.cache.Get(key)
+	if !ok {
+		return false
+	}
+
+	return json.Unmarshal(b, out) == nil
+}
+
+func (c *client) cacheSet(key string, v interface{}) {
+
+	if c.options.cache == nil {
+		return
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	c.options.cache.Set(key, b)
+}
+
+// observeHead records a block number known to exist.
+func (c *client) observeHead(number int64) {
+	for {
+		head := c.head.Load()
+		if number <= head || c.head.CompareAndSwap(head, number) {
+			return
+		}
+	}
+}
+
+// isSolidified reports whether the block number is known to be below the
+// solidified head.
+func (c *client) isSolidified(number int64) bool {
+	head := c.head.Load()
+	return head > 0 && number <= head-solidityConfirmations
+}
+
+type lruCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+// NewLRUCache returns an in-memory Cache holding up to size entries.
+// Sizes below 1 are treated as 1.
+func NewLRUCache(size int) Cache {
+	return &lruCache{
+		size:    max(size, 1),
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *lruCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	c.order.MoveToFront(e)
+
+	return e.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) Set(key string, value []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lruEntry).value = value
+		c.order.MoveToFront(e)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{key: key, value: value})
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+type diskCache struct {
+	dir string
+}
+
+// NewDiskCache returns a Cache storing one file per entry in dir, which is
+// created if needed. Entries are never evicted.
+func NewDiskCache(dir string) (Cache, error) {
+
+	err := os.MkdirAll(dir, 0o755)
+	if err != nil {
+		return nil, err
+	}
+
+	return &diskCache{dir: dir}, nil
+}
+
+func (c *diskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *diskCache) Get(key string) ([]byte, bool) {
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	return b, true
+}
+
+func (c *diskCache) Set(key string, value []byte) {
+
+	f, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = f.Write(value)
+	closeErr := f.Close()
+
+	if err != nil || closeErr != nil {
+		_ = os.Remove(f.Name())
+		return
+	}
+
+	// Rename is atomic, so readers never see a partially written entry.
+	if os.Rename(f.Name(), c.path(key)) != nil {
+		_ = os.Remove(f.Name())
+	}
+}
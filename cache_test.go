@@ -0,0 +1,90 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"))
+	c.Set("b", []byte("2"))
+
+	// Reading a makes b the least recently used entry.
+	if v, ok := c.Get("a"); !ok || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %t", v, ok)
+	}
+
+	c.Set("c", []byte("3"))
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("b was not evicted")
+	}
+
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+}
+
+func TestLRUCacheSmallSize(t *testing.T) {
+
+	for _, size := range []int{-1, 0, 1} {
+		c := NewLRUCache(size)
+		c.Set("a", []byte("1"))
+		c.Set("b", []byte("2"))
+
+		if _, ok := c.Get("b"); !ok {
+			t.Errorf("NewLRUCache(%d) did not keep the latest entry", size)
+		}
+
+		if _, ok := c.Get("a"); ok {
+			t.Errorf("NewLRUCache(%d) kept more than one entry", size)
+		}
+	}
+}
+
+func TestGetContractCache(t *testing.T) {
+
+	server, count := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		fmt.Fprintf(w, `{"contract_address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t","name":"TetherToken","origin_energy_limit":%d}`, n)
+	})
+
+	c := New(WithBaseURL(server.URL), WithCache(NewLRUCache(10))).(*client)
+	address := MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	for range 2 {
+		contract, err := c.GetContract(context.Background(), address)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if contract.OriginEnergyLimit != 1 {
+			t.Errorf("OriginEnergyLimit = %d, want the cached 1", contract.OriginEnergyLimit)
+		}
+	}
+
+	if count.Load() != 1 {
+		t.Errorf("sent %d requests, want 1", count.Load())
+	}
+
+	// An expired entry is fetched again.
+	c.cacheSet(c.cacheKey("GetContract", address), cachedContract{
+		Contract: &SmartContract{ContractAddress: address},
+		Expires:  time.Now().Add(-time.Second),
+	})
+
+	contract, err := c.GetContract(context.Background(), address)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if contract.OriginEnergyLimit != 2 || count.Load() != 2 {
+		t.Errorf("OriginEnergyLimit = %d after %d requests, want 2 after 2", contract.OriginEnergyLimit, count.Load())
+	}
+}
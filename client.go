@@ -4,342 +4,654 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
 type client struct {
 	options *clientOptions
+	pool    *endpointPool
+	doer    Doer
+
+	// head is the highest block number seen, used to tell which blocks
+	// are solidified and safe to cache.
+	head atomic.Int64
 }
 
-func (c *client) GetBlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+// apiCall is a single logical request sent through do.
+type apiCall struct {
+	// op is the client method the call is made for.
+	op string
+	// page is the cursor page index the call fetches, if any.
+	page int
+	req  *http.Request
+	// idempotent calls are retried on any transient failure.
+	idempotent bool
+	// v1 calls use the TronGrid /v1 API rather than the full node API.
+	v1 bool
+	// sticky, when set, pins the call to the endpoint it points to and
+	// receives the endpoint that served the call.
+	sticky **endpointState
+}
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
+// do sends the call and returns the body of a successful response.
+// Transport errors are returned as is, failed responses as an *APIError.
+// Failed attempts are retried according to the client's RetryPolicy and,
+// with multiple endpoints configured, failed over to the next endpoint;
+// calls that are not idempotent are only resent when the error shows the
+// node did not accept them.
+func (c *client) do(ctx context.Context, call apiCall) ([]byte, error) {
+
+	policy := c.options.retryPolicy
+	tried := make(map[*endpointState]bool)
+	triedKeys := make(map[*pooledKeyState]bool)
+
+	for attempt, sent := 1, 1; ; sent++ {
+
+		attemptCtx := contextWithRequestInfo(ctx, RequestInfo{
+			Operation: call.op,
+			Network:   c.options.network,
+			Attempt:   sent,
+			Page:      call.page,
+		})
+
+		if c.options.rateLimiter != nil {
+			err := c.options.rateLimiter.Wait(attemptCtx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		endpoint := c.pickEndpoint(call, tried)
+		if c.pool != nil && endpoint == nil {
+			return nil, fmt.Errorf("%w for %s", ErrNoEndpoint, call.req.URL.Path)
+		}
+
+		key := c.pickKey(endpoint, triedKeys)
+		if key != nil {
+			err := key.wait(attemptCtx)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.doAttempt(attemptCtx, call.req, endpoint, key)
+
+		if key != nil {
+			c.options.keyPool.report(key, err)
+		}
+
+		if err == nil {
+			if call.sticky != nil {
+				*call.sticky = endpoint
+			}
+
+			return body, nil
+		}
+
+		if ctx.Err() != nil {
 			return nil, err
 		}
-	}
 
-	endpoint := fmt.Sprintf("%s/wallet/getblockbynum", c.options.fullNodeBaseURL)
+		resendable := call.idempotent || isNotAccepted(err)
 
-	reqBody := map[string]interface{}{
-		"num": number,
-	}
+		if endpoint != nil && isEndpointFailure(err) {
+			c.pool.markDown(endpoint)
+			tried[endpoint] = true
 
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, err
+			if !c.pool.hasCandidate(call.v1, tried) {
+				// Every endpoint failed this attempt.
+				err = fmt.Errorf("%w: %w", ErrNoEndpoint, err)
+			} else if resendable {
+				c.logFailover(ctx, call, endpoint, err)
+				continue
+			}
+		}
+
+		// A rejected key is rotated out and the call resent with the next
+		// key after a backoff. The resend counts against MaxAttempts.
+		if key != nil && isKeyRejected(err) && resendable && attempt < policy.MaxAttempts {
+			triedKeys[key] = true
+
+			if len(triedKeys) < len(c.options.keyPool.keys) {
+				delay := policy.backoff(len(triedKeys), err)
+				c.logRetry(ctx, call, attempt, delay, err)
+
+				if !sleep(ctx, delay) {
+					return nil, err
+				}
+
+				attempt++
+				continue
+			}
+		}
+
+		if attempt >= policy.MaxAttempts || !isRetryable(err) || !resendable {
+			return nil, err
+		}
+
+		attempt++
+		clear(tried)
+		clear(triedKeys)
+
+		delay := policy.backoff(attempt-1, err)
+		c.logRetry(ctx, call, attempt-1, delay, err)
+
+		if !sleep(ctx, delay) {
+			return nil, err
+		}
 	}
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
+// sleep waits for d and reports whether it did before ctx was done.
+func sleep(ctx context.Context, d time.Duration) bool {
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
+}
 
-	req.Header.Set("Content-Type", "application/json")
+// pickEndpoint returns the endpoint for the next attempt of call, or nil
+// when the client talks to a single base URL.
+func (c *client) pickEndpoint(call apiCall, tried map[*endpointState]bool) *endpointState {
 
-	if c.options.apiKey != "" {
-		req.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+	if c.pool == nil {
+		return nil
 	}
 
-	resp, err := c.options.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	c.pool.maybeCheckHealth(c)
+
+	if call.sticky != nil && *call.sticky != nil && !tried[*call.sticky] && c.pool.available(*call.sticky) {
+		return *call.sticky
 	}
 
-	defer resp.Body.Close()
+	return c.pool.pick(call.v1, tried)
+}
 
-	var block Block
-	err = json.NewDecoder(resp.Body).Decode(&block)
-	if err != nil {
-		return nil, err
+// pickKey returns the pooled API key for the next attempt, or nil when no
+// key pool is configured or the endpoint has its own key.
+func (c *client) pickKey(endpoint *endpointState, tried map[*pooledKeyState]bool) *pooledKeyState {
+
+	if c.options.keyPool == nil || len(c.options.keyPool.keys) == 0 {
+		return nil
 	}
 
-	return &block, nil
+	if endpoint != nil && endpoint.APIKey != "" {
+		return nil
+	}
+
+	return c.options.keyPool.pick(tried)
 }
 
-func (c *client) GetAccountBalance(ctx context.Context, address string, blockNumber uint64, blockHash string) (*AccountBalance, error) {
+// doAttempt sends a single attempt of req to the endpoint, or to the URL
+// of req if endpoint is nil.
+func (c *client) doAttempt(ctx context.Context, req *http.Request, endpoint *endpointState, key *pooledKeyState) ([]byte, error) {
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
+	if endpoint != nil && endpoint.err != nil {
+		return nil, endpoint.err
+	}
+
+	req = req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
 		if err != nil {
 			return nil, err
 		}
-	}
-
-	endpoint := fmt.Sprintf("%s/wallet/getaccountbalance", c.options.fullNodeBaseURL)
 
-	reqBody := map[string]interface{}{
-		"account_identifier": map[string]interface{}{
-			"address": address,
-		},
-		"block_identifier": map[string]interface{}{
-			"number": blockNumber,
-			"hash":   blockHash,
-		},
-		"visible": true,
+		req.Body = body
 	}
 
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, err
+	if endpoint != nil {
+		req.URL = endpoint.rebase(req.URL)
+		req.Host = ""
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
+	apiKey := c.apiKeyFor(endpoint)
+	if key != nil {
+		apiKey = key.Key
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	if c.options.apiKey != "" {
-		req.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+	if apiKey != "" {
+		req.Header.Set("TRON-PRO-API-KEY", apiKey)
 	}
 
-	resp, err := c.options.httpClient.Do(req)
+	started := time.Now()
+
+	resp, err := c.doer.Do(req)
 	if err != nil {
+		c.logRequest(ctx, req.Method, req.URL, 0, 0, time.Since(started), err)
 		return nil, err
 	}
 
 	defer resp.Body.Close()
 
-	b, err := io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	c.logRequest(ctx, req.Method, req.URL, resp.StatusCode, len(body), time.Since(started), err)
 	if err != nil {
 		return nil, err
 	}
 
-	var accountBalance AccountBalance
-	err = json.Unmarshal(b, &accountBalance)
+	err = checkResponse(req.URL.String(), resp.StatusCode, body)
 	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			apiErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		}
+
 		return nil, err
 	}
 
-	return &accountBalance, nil
+	return body, nil
 }
 
-func (c *client) GetAccount(ctx context.Context, address string) (*Account, error) {
-
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			return nil, err
-		}
+// apiKeyFor returns the API key to send to the endpoint.
+func (c *client) apiKeyFor(endpoint *endpointState) string {
+	if endpoint != nil && endpoint.APIKey != "" {
+		return endpoint.APIKey
 	}
 
-	endpoint := fmt.Sprintf("%s/wallet/getaccount", c.options.fullNodeBaseURL)
-	body := map[string]interface{}{"address": address, "visible": true}
-	jsonData, _ := json.Marshal(body)
+	return c.options.apiKey
+}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, err
+// baseURL returns the base URL requests are built against. With multiple
+// endpoints configured, requests are built with a relative URL and
+// rebased onto the endpoint picked for each attempt.
+func (c *client) baseURL(v1 bool) string {
+	if c.pool != nil {
+		return ""
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	if v1 {
+		return c.options.baseURL
+	}
+
+	return c.options.fullNodeBaseURL
+}
+
+// post sends reqBody as JSON to the full node path and decodes the
+// response into out. A nil reqBody sends an empty request.
+func (c *client) post(ctx context.Context, op, path string, reqBody interface{}, out interface{}, idempotent bool) error {
+
+	endpoint := fmt.Sprintf("%s%s", c.baseURL(false), path)
+
+	var bodyReader io.Reader
+	if reqBody != nil {
+		body, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
 
-	if c.options.apiKey != "" {
-		req.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+		bodyReader = bytes.NewReader(body)
 	}
 
-	resp, err := c.options.httpClient.Do(req)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bodyReader)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	defer resp.Body.Close()
-
-	rspBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	var account Account
-	err = json.Unmarshal(rspBody, &account)
+	body, err := c.do(ctx, apiCall{op: op, req: req, idempotent: idempotent})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &account, nil
+	return c.decode(ctx, op, body, out)
 }
 
-func (c *client) GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error) {
+func (c *client) GetBlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	return c.getBlockByNumber(ctx, c.options.consistency.path(), number)
+}
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			return nil, err
-		}
+func (c *client) getBlockByNumber(ctx context.Context, wallet string, number uint64) (*Block, error) {
+
+	cacheKey := c.cacheKey("GetBlockByNumber", number)
+	cacheable := c.isSolidified(int64(number))
+
+	var block Block
+	if cacheable && c.cacheGet(cacheKey, &block) {
+		block.Consistency = ConsistencySolidified
+		return &block, nil
 	}
 
-	endpoint := fmt.Sprintf("%s/wallet/gettransactioninfobyid", c.options.fullNodeBaseURL)
+	reqBody := map[string]interface{}{
+		"num": number,
+	}
 
-	body, err := json.Marshal(map[string]string{"value": txID})
+	err := c.post(ctx, "GetBlockByNumber", wallet+"/getblockbynum", reqBody, &block, true)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
+	// The node answers {} for blocks it does not have yet, which for the
+	// solidity node includes every block that is not solidified.
+	if block.BlockID == "" {
+		endpoint := fmt.Sprintf("%s%s/getblockbynum", c.baseURL(false), wallet)
+		apiErr := newAPIError(endpoint, http.StatusOK, "", fmt.Sprintf("block %d not found", number))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	block.Consistency = consistencyOf(wallet)
+
+	if block.BlockHeader != nil && block.BlockHeader.RawData != nil {
+		c.observeHead(int64(block.BlockHeader.RawData.Number))
+
+		if cacheable {
+			c.cacheSet(cacheKey, &block)
+		}
+	}
+
+	return &block, nil
+}
+
+func (c *client) GetBlock(ctx context.Context, idOrNum string, detail bool) (*Block, error) {
+	return c.getBlock(ctx, c.options.consistency.path(), idOrNum, detail)
+}
+
+func (c *client) getBlock(ctx context.Context, wallet, idOrNum string, detail bool) (*Block, error) {
 
-	if c.options.apiKey != "" {
-		req.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+	reqBody := map[string]interface{}{
+		"id_or_num": idOrNum,
+		"detail":    detail,
 	}
 
-	resp, err := c.options.httpClient.Do(req)
+	var block Block
+	err := c.post(ctx, "GetBlock", wallet+"/getblock", reqBody, &block, true)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
+	// The node answers {} for unknown blocks.
+	if block.BlockID == "" {
+		endpoint := fmt.Sprintf("%s%s/getblock", c.baseURL(false), wallet)
+		apiErr := newAPIError(endpoint, http.StatusOK, "", fmt.Sprintf("block %s not found", idOrNum))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
+	}
 
-	var getTransactionInfoByIDResponse GetTransactionInfoByIDResponse
-	err = json.NewDecoder(resp.Body).Decode(&getTransactionInfoByIDResponse)
-	if err != nil {
-		return nil, err
+	block.Consistency = consistencyOf(wallet)
+
+	if block.BlockHeader != nil && block.BlockHeader.RawData != nil {
+		c.observeHead(int64(block.BlockHeader.RawData.Number))
 	}
 
-	return &getTransactionInfoByIDResponse, nil
+	return &block, nil
+}
 
+func (c *client) GetBlocksByRange(ctx context.Context, start, end uint64) ([]*Block, error) {
+	return c.getBlocksByRange(ctx, c.options.consistency.path(), start, end)
 }
 
-func (c *client) TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+func (c *client) getBlocksByRange(ctx context.Context, wallet string, start, end uint64) ([]*Block, error) {
+
+	if end < start {
+		return nil, fmt.Errorf("invalid block range [%d, %d)", start, end)
+	}
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
+	blocks := make([]*Block, 0, min(end-start, maxBlocksByRange))
+
+	for from := start; from < end; from += min(end-from, maxBlocksByRange) {
+		reqBody := map[string]interface{}{
+			"startNum": from,
+			"endNum":   from + min(end-from, maxBlocksByRange),
+		}
+
+		var list blockList
+		err := c.post(ctx, "GetBlocksByRange", wallet+"/getblockbylimitnext", reqBody, &list, true)
 		if err != nil {
 			return nil, err
 		}
+
+		blocks = append(blocks, c.blocksReceived(wallet, list.Block)...)
 	}
 
-	endpoint := fmt.Sprintf("%s/wallet/triggerconstantcontract", c.options.fullNodeBaseURL)
+	sortBlocks(blocks)
 
-	body, err := json.Marshal(req)
-	if err != nil {
-		return nil, err
-	}
+	return blocks, nil
+}
 
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
-	if err != nil {
-		return nil, err
+func (c *client) GetLatestBlocks(ctx context.Context, n int) ([]*Block, error) {
+	return c.getLatestBlocks(ctx, c.options.consistency.path(), n)
+}
+
+func (c *client) getLatestBlocks(ctx context.Context, wallet string, n int) ([]*Block, error) {
+
+	if n < 0 {
+		return nil, fmt.Errorf("invalid block count %d", n)
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
+	if n == 0 {
+		return []*Block{}, nil
+	}
 
-	if c.options.apiKey != "" {
-		httpReq.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+	reqBody := map[string]interface{}{
+		"num": min(n, maxBlocksByLatest),
 	}
 
-	resp, err := c.options.httpClient.Do(httpReq)
+	var list blockList
+	err := c.post(ctx, "GetLatestBlocks", wallet+"/getblockbylatestnum", reqBody, &list, true)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
+	blocks := c.blocksReceived(wallet, list.Block)
+	sortBlocks(blocks)
 
-	var triggerConstantContractResponse TriggerConstantContractResponse
-	err = json.NewDecoder(resp.Body).Decode(&triggerConstantContractResponse)
+	start, end, ok := olderRange(blocks, n)
+	if !ok {
+		return blocks, nil
+	}
+
+	older, err := c.getBlocksByRange(ctx, wallet, start, end)
 	if err != nil {
 		return nil, err
 	}
 
-	return &triggerConstantContractResponse, nil
+	return append(older, blocks...), nil
 }
 
-func (c *client) BroadcastHex(ctx context.Context, broadcastHexRequest *BroadcastHexRequest) (*BroadcastHexResponse, error) {
+// blocksReceived sets the consistency of blocks read from wallet, records
+// the head and caches solidified blocks for GetBlockByNumber.
+func (c *client) blocksReceived(wallet string, blocks []*Block) []*Block {
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			return nil, err
+	for _, block := range blocks {
+		block.Consistency = consistencyOf(wallet)
+		c.observeHead(block.Number())
+	}
+
+	for _, block := range blocks {
+		if block.Number() >= 0 && c.isSolidified(block.Number()) {
+			c.cacheSet(c.cacheKey("GetBlockByNumber", uint64(block.Number())), block)
 		}
 	}
 
-	endpoint := fmt.Sprintf("%s/wallet/broadcasthex", c.options.fullNodeBaseURL)
+	return blocks
+}
+
+func (c *client) GetAccountBalance(ctx context.Context, address Address, blockNumber uint64, blockHash string) (*AccountBalance, error) {
+
+	reqBody := map[string]interface{}{
+		"account_identifier": map[string]interface{}{
+			"address": address.String(),
+		},
+		"block_identifier": map[string]interface{}{
+			"number": blockNumber,
+			"hash":   blockHash,
+		},
+		"visible": true,
+	}
 
-	body, err := json.Marshal(broadcastHexRequest)
+	var accountBalance AccountBalance
+	err := c.post(ctx, "GetAccountBalance", "/wallet/getaccountbalance", reqBody, &accountBalance, true)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	return &accountBalance, nil
+}
+
+func (c *client) GetAccount(ctx context.Context, address Address) (*Account, error) {
+	return c.getAccount(ctx, c.options.consistency.path(), address)
+}
+
+func (c *client) getAccount(ctx context.Context, wallet string, address Address) (*Account, error) {
+
+	reqBody := map[string]interface{}{"address": address.String(), "visible": true}
+
+	var account Account
+	err := c.post(ctx, "GetAccount", wallet+"/getaccount", reqBody, &account, true)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	account.Consistency = consistencyOf(wallet)
+
+	return &account, nil
+}
+
+func (c *client) GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error) {
+	return c.getTransactionInfoByID(ctx, c.options.consistency.path(), txID)
+}
+
+func (c *client) getTransactionInfoByID(ctx context.Context, wallet string, txID string) (*GetTransactionInfoByIDResponse, error) {
 
-	if c.options.apiKey != "" {
-		req.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+	cacheKey := c.cacheKey("GetTransactionInfoByID", txID)
+
+	var getTransactionInfoByIDResponse GetTransactionInfoByIDResponse
+	if c.cacheGet(cacheKey, &getTransactionInfoByIDResponse) {
+		getTransactionInfoByIDResponse.Consistency = ConsistencySolidified
+		return &getTransactionInfoByIDResponse, nil
 	}
 
-	resp, err := c.options.httpClient.Do(req)
+	reqBody := map[string]string{"value": txID}
+
+	err := c.post(ctx, "GetTransactionInfoByID", wallet+"/gettransactioninfobyid", reqBody, &getTransactionInfoByIDResponse, true)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
+	// The node answers {} for transactions it has not seen in a block yet.
+	if getTransactionInfoByIDResponse.Id == "" {
+		endpoint := fmt.Sprintf("%s%s/gettransactioninfobyid", c.baseURL(false), wallet)
+		apiErr := newAPIError(endpoint, http.StatusOK, "", fmt.Sprintf("transaction %s not found", txID))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
+	}
 
-	var broadcastHexResponse BroadcastHexResponse
-	err = json.NewDecoder(resp.Body).Decode(&broadcastHexResponse)
-	if err != nil {
-		return nil, err
+	getTransactionInfoByIDResponse.Consistency = consistencyOf(wallet)
+
+	if c.isSolidified(int64(getTransactionInfoByIDResponse.BlockNumber)) {
+		c.cacheSet(cacheKey, &getTransactionInfoByIDResponse)
 	}
 
-	return &broadcastHexResponse, nil
+	return &getTransactionInfoByIDResponse, nil
+
 }
 
-func (c *client) GetNowBlock(ctx context.Context) (*Block, error) {
+func (c *client) GetContract(ctx context.Context, address Address) (*SmartContract, error) {
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			return nil, err
-		}
+	cacheKey := c.cacheKey("GetContract", address)
+
+	var cached cachedContract
+	if c.cacheGet(cacheKey, &cached) && cached.Contract != nil && time.Now().Before(cached.Expires) {
+		return cached.Contract, nil
 	}
 
-	endpoint := fmt.Sprintf("%s/wallet/getnowblock", c.options.fullNodeBaseURL)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	var contract SmartContract
+
+	reqBody := map[string]interface{}{"value": address.String(), "visible": true}
+
+	err := c.post(ctx, "GetContract", "/wallet/getcontract", reqBody, &contract, true)
 	if err != nil {
 		return nil, err
 	}
 
-	if c.options.apiKey != "" {
-		req.Header.Set("TRON-PRO-API-KEY", c.options.apiKey)
+	// The node answers {} for addresses without a contract.
+	if contract.ContractAddress.IsZero() {
+		endpoint := fmt.Sprintf("%s/wallet/getcontract", c.baseURL(false))
+		apiErr := newAPIError(endpoint, http.StatusOK, "", fmt.Sprintf("contract %s not found", address))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
 	}
 
-	resp, err := c.options.httpClient.Do(req)
+	c.cacheSet(cacheKey, cachedContract{Contract: &contract, Expires: time.Now().Add(contractCacheTTL)})
+
+	return &contract, nil
+}
+
+// cachedContract is a GetContract result in the cache.
+type cachedContract struct {
+	Contract *SmartContract `json:"contract"`
+	Expires  time.Time      `json:"expires"`
+}
+
+func (c *client) TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+	return c.triggerConstantContract(ctx, c.options.consistency.path(), req)
+}
+
+func (c *client) triggerConstantContract(ctx context.Context, wallet string, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+
+	var triggerConstantContractResponse TriggerConstantContractResponse
+	err := c.post(ctx, "TriggerConstantContract", wallet+"/triggerconstantcontract", req, &triggerConstantContractResponse, true)
 	if err != nil {
 		return nil, err
 	}
 
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API request failed with status code: %d, response: %s", resp.StatusCode, string(body))
+	result := triggerConstantContractResponse.Result
+	if result.Code != "" && result.Code != CodeSuccess {
+		endpoint := fmt.Sprintf("%s%s/triggerconstantcontract", c.baseURL(false), wallet)
+		return nil, newAPIError(endpoint, http.StatusOK, result.Code, decodeMessage(result.Message))
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	triggerConstantContractResponse.Consistency = consistencyOf(wallet)
+
+	return &triggerConstantContractResponse, nil
+}
+
+func (c *client) BroadcastHex(ctx context.Context, broadcastHexRequest *BroadcastHexRequest) (*BroadcastHexResponse, error) {
+
+	var broadcastHexResponse BroadcastHexResponse
+	err := c.post(ctx, "BroadcastHex", "/wallet/broadcasthex", broadcastHexRequest, &broadcastHexResponse, false)
 	if err != nil {
 		return nil, err
 	}
 
+	return &broadcastHexResponse, nil
+}
+
+func (c *client) GetNowBlock(ctx context.Context) (*Block, error) {
+	return c.getNowBlock(ctx, c.options.consistency.path())
+}
+
+func (c *client) getNowBlock(ctx context.Context, wallet string) (*Block, error) {
+
 	var block Block
-	err = json.Unmarshal(body, &block)
+	err := c.post(ctx, "GetNowBlock", wallet+"/getnowblock", nil, &block, true)
 	if err != nil {
 		return nil, err
 	}
 
 	if block.BlockHeader == nil || block.BlockHeader.RawData == nil {
-		return nil, fmt.Errorf("%w, response: %s", ErrNoDataInResponse, string(body))
+		return nil, fmt.Errorf("%w: block %q has no header", ErrNoDataInResponse, block.BlockID)
 	}
 
+	c.observeHead(int64(block.BlockHeader.RawData.Number))
+
+	block.Consistency = consistencyOf(wallet)
+
 	return &block, nil
 }
 
@@ -356,7 +668,18 @@ func New(opts ...ClientOption) Client {
 		opt(options)
 	}
 
-	return &client{
+	if options.fullNodeBaseURL == "" {
+		options.fullNodeBaseURL = options.baseURL
+	}
+
+	c := &client{
 		options: options,
+		doer:    chainMiddlewares(options.httpClient, options.middlewares),
 	}
+
+	if len(options.endpoints) > 0 {
+		c.pool = newEndpointPool(options)
+	}
+
+	return c
 }
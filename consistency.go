@@ -0,0 +1,57 @@
+package trongrid
+
+// Consistency is the confirmation level of the state a read is served
+// from.
+type Consistency string
+
+const (
+	// ConsistencyLatest reads the head of the chain from /wallet. Recent
+	// blocks may still be reverted.
+	ConsistencyLatest Consistency = "latest"
+	// ConsistencyPBFT reads the state finalized by PBFT consensus from
+	// /walletpbft, a few seconds behind the head.
+	ConsistencyPBFT Consistency = "pbft"
+	// ConsistencySolidified reads the solidified state from
+	// /walletsolidity, which can no longer be reverted.
+	ConsistencySolidified Consistency = "solidified"
+)
+
+// WithConsistency sets the level GetNowBlock, GetBlockByNumber, GetBlock,
+// GetBlocksByRange, GetLatestBlocks, GetAccount, TriggerConstantContract
+// and GetTransactionInfoByID read at. The other methods always use the
+// latest state. The level that served an answer is reported in its
+// Consistency field; answers from the cache report ConsistencySolidified.
+//
+// With NewGRPC, ConsistencyPBFT and ConsistencySolidified use the
+// WalletSolidity service, which java-tron serves for PBFT and solidified
+// state on different ports, so conn must point at the matching one. It has
+// no equivalent of GetBlocksByRange and GetLatestBlocks, which then return
+// ErrNotSupported.
+func WithConsistency(level Consistency) ClientOption {
+	return func(o *clientOptions) {
+		o.consistency = level
+	}
+}
+
+// path returns the HTTP API path prefix serving the level.
+func (level Consistency) path() string {
+	switch level {
+	case ConsistencyPBFT:
+		return walletPBFTPath
+	case ConsistencySolidified:
+		return walletSolidityPath
+	}
+
+	return walletPath
+}
+
+func consistencyOf(path string) Consistency {
+	switch path {
+	case walletPBFTPath:
+		return ConsistencyPBFT
+	case walletSolidityPath:
+		return ConsistencySolidified
+	}
+
+	return ConsistencyLatest
+}
@@ -0,0 +1,33 @@
+package trongrid
+
+import "net/url"
+
+// fingerprintOf returns the fingerprint query parameter of a page URL.
+func fingerprintOf(pageURL string) string {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Query().Get("fingerprint")
+}
+
+// withFingerprint returns pageURL with its fingerprint query parameter
+// replaced, or removed if fingerprint is empty.
+func withFingerprint(pageURL, fingerprint string) string {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return pageURL
+	}
+
+	q := u.Query()
+	if fingerprint == "" {
+		q.Del("fingerprint")
+	} else {
+		q.Set("fingerprint", fingerprint)
+	}
+
+	u.RawQuery = q.Encode()
+
+	return u.String()
+}
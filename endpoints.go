@@ -0,0 +1,338 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// endpointCooldown is how long an endpoint that failed at the transport
+	// level is skipped before it is tried again.
+	endpointCooldown = 30 * time.Second
+	// healthCheckTimeout bounds a single health check round.
+	healthCheckTimeout = 10 * time.Second
+)
+
+// Endpoint is a full node or TronGrid deployment the client sends requests
+// to when configured with WithEndpoints.
+type Endpoint struct {
+	// URL is the base URL of the endpoint, e.g. https://api.trongrid.io.
+	URL string
+	// APIKey overrides the client API key for this endpoint.
+	APIKey string
+	// Weight is the relative share of requests sent to the endpoint. Zero
+	// is treated as 1.
+	Weight int
+	// FullNodeOnly marks plain java-tron nodes that do not serve the
+	// TronGrid /v1 API used by the transaction cursors.
+	FullNodeOnly bool
+}
+
+// WithEndpoints load balances requests across the given endpoints by
+// weight and fails over to the next one on transport errors and 5xx
+// responses. It replaces WithBaseURL and the network base URL. Calls no
+// endpoint can serve, such as /v1 calls when every endpoint is
+// FullNodeOnly, and calls that failed on every endpoint fail with
+// ErrNoEndpoint, wrapping the last endpoint's error in the latter case.
+func WithEndpoints(endpoints ...Endpoint) ClientOption {
+	return func(o *clientOptions) {
+		o.endpoints = endpoints
+	}
+}
+
+// WithHealthCheck enables endpoint health checking. Every interval the
+// head block of each endpoint is fetched with GetNowBlock; endpoints more
+// than maxBlockLag blocks behind the highest head are avoided until they
+// catch up.
+func WithHealthCheck(interval time.Duration, maxBlockLag int64) ClientOption {
+	return func(o *clientOptions) {
+		o.healthCheckInterval = interval
+		o.maxBlockLag = maxBlockLag
+	}
+}
+
+// WithStickyCursors makes transaction cursors fetch all pages from the
+// endpoint that served their first page, as long as it stays available.
+func WithStickyCursors(sticky bool) ClientOption {
+	return func(o *clientOptions) {
+		o.stickyCursors = sticky
+	}
+}
+
+type endpointState struct {
+	Endpoint
+
+	base *url.URL
+	// err is set when URL cannot be parsed; every request to the
+	// endpoint fails with it.
+	err error
+
+	// Guarded by endpointPool.mu.
+	currentWeight int
+	downUntil     time.Time
+	lagging       bool
+}
+
+type endpointPool struct {
+	mu        sync.Mutex
+	endpoints []*endpointState
+
+	healthCheckInterval time.Duration
+	maxBlockLag         int64
+	lastHealthCheck     time.Time
+	checking            bool
+}
+
+func newEndpointPool(options *clientOptions) *endpointPool {
+
+	pool := &endpointPool{
+		healthCheckInterval: options.healthCheckInterval,
+		maxBlockLag:         options.maxBlockLag,
+	}
+
+	for _, endpoint := range options.endpoints {
+		if endpoint.Weight <= 0 {
+			endpoint.Weight = 1
+		}
+
+		e := &endpointState{Endpoint: endpoint}
+
+		base, err := url.Parse(strings.TrimSuffix(endpoint.URL, "/"))
+		if err != nil {
+			e.err = fmt.Errorf("invalid endpoint %q: %w", endpoint.URL, err)
+		} else {
+			e.base = base
+		}
+
+		pool.endpoints = append(pool.endpoints, e)
+	}
+
+	return pool
+}
+
+// pick selects the next endpoint using smooth weighted round-robin.
+// Endpoints that are down or lagging are only used when nothing else is
+// left. Endpoints in tried are never returned.
+func (p *endpointPool) pick(v1 bool, tried map[*endpointState]bool) *endpointState {
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	var healthy, fallback []*endpointState
+	for _, e := range p.endpoints {
+		if tried[e] || (v1 && e.FullNodeOnly) {
+			continue
+		}
+
+		if e.lagging || now.Before(e.downUntil) {
+			fallback = append(fallback, e)
+		} else {
+			healthy = append(healthy, e)
+		}
+	}
+
+	candidates := healthy
+	if len(candidates) == 0 {
+		candidates = fallback
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	total := 0
+	var best *endpointState
+	for _, e := range candidates {
+		e.currentWeight += e.Weight
+		total += e.Weight
+
+		if best == nil || e.currentWeight > best.currentWeight {
+			best = e
+		}
+	}
+
+	best.currentWeight -= total
+
+	return best
+}
+
+// hasCandidate reports whether pick would return an endpoint.
+func (p *endpointPool) hasCandidate(v1 bool, tried map[*endpointState]bool) bool {
+	for _, e := range p.endpoints {
+		if !tried[e] && !(v1 && e.FullNodeOnly) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *endpointPool) markDown(e *endpointState) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.downUntil = time.Now().Add(endpointCooldown)
+}
+
+func (p *endpointPool) available(e *endpointState) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return !e.lagging && !time.Now().Before(e.downUntil)
+}
+
+// maybeCheckHealth starts a background health check round if one is due.
+func (p *endpointPool) maybeCheckHealth(c *client) {
+
+	if p.healthCheckInterval <= 0 {
+		return
+	}
+
+	p.mu.Lock()
+	if p.checking || time.Since(p.lastHealthCheck) < p.healthCheckInterval {
+		p.mu.Unlock()
+		return
+	}
+	p.checking = true
+	p.mu.Unlock()
+
+	go p.checkHealth(c)
+}
+
+func (p *endpointPool) checkHealth(c *client) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+
+	heights := make([]int64, len(p.endpoints))
+	errs := make([]error, len(p.endpoints))
+
+	var wg sync.WaitGroup
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func(i int, e *endpointState) {
+			defer wg.Done()
+			heights[i], errs[i] = c.endpointHeight(ctx, e)
+		}(i, e)
+	}
+	wg.Wait()
+
+	var highest int64
+	for i := range p.endpoints {
+		if errs[i] == nil && heights[i] > highest {
+			highest = heights[i]
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	for i, e := range p.endpoints {
+		if errs[i] != nil {
+			e.downUntil = now.Add(endpointCooldown)
+			continue
+		}
+
+		e.lagging = highest-heights[i] > p.maxBlockLag
+	}
+
+	p.lastHealthCheck = now
+	p.checking = false
+}
+
+// endpointHeight fetches the head block number of a single endpoint,
+// bypassing the rate limiter, retries and failover. The request still goes
+// through the middleware chain.
+func (c *client) endpointHeight(ctx context.Context, e *endpointState) (int64, error) {
+
+	if e.err != nil {
+		return 0, e.err
+	}
+
+	ctx = contextWithRequestInfo(ctx, RequestInfo{
+		Operation: "HealthCheck",
+		Network:   c.options.network,
+		Attempt:   1,
+	})
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.base.String()+"/wallet/getnowblock", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if apiKey := c.apiKeyFor(e); apiKey != "" {
+		req.Header.Set("TRON-PRO-API-KEY", apiKey)
+	}
+
+	resp, err := c.doer.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	err = checkResponse(req.URL.String(), resp.StatusCode, body)
+	if err != nil {
+		return 0, err
+	}
+
+	var block Block
+	err = json.Unmarshal(body, &block)
+	if err != nil {
+		return 0, err
+	}
+
+	if block.BlockHeader == nil || block.BlockHeader.RawData == nil {
+		return 0, ErrNoDataInResponse
+	}
+
+	return int64(block.BlockHeader.RawData.Number), nil
+}
+
+// rebase points u at the endpoint, keeping its path and query.
+func (e *endpointState) rebase(u *url.URL) *url.URL {
+	r := *u
+	r.Scheme = e.base.Scheme
+	r.Host = e.base.Host
+	r.User = e.base.User
+	r.Path = e.base.Path + u.Path
+	r.RawPath = ""
+	return &r
+}
+
+// isEndpointFailure reports whether err indicates the endpoint itself is
+// unavailable, as opposed to the request being rejected.
+func isEndpointFailure(err error) bool {
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
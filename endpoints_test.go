@@ -0,0 +1,96 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// closedURL returns the URL of a server that is no longer listening, so
+// that requests to it fail to dial.
+func closedURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func TestEndpointFailover(t *testing.T) {
+
+	tests := []struct {
+		name string
+		down func(t *testing.T) string
+	}{
+		{name: "dial error", down: func(t *testing.T) string { return closedURL() }},
+		{
+			name: "bad gateway",
+			down: func(t *testing.T) string {
+				server, _ := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+					w.WriteHeader(http.StatusBadGateway)
+				})
+				return server.URL
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			up, count := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+				fmt.Fprint(w, nowBlockJSON)
+			})
+
+			// Failover does not need retries.
+			c := New(
+				WithEndpoints(Endpoint{URL: tt.down(t)}, Endpoint{URL: up.URL}),
+				WithRetryPolicy(RetryPolicy{MaxAttempts: 1}),
+			)
+
+			// Whichever endpoint is picked first, every call succeeds.
+			for range 4 {
+				_, err := c.GetNowBlock(context.Background())
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if count.Load() != 4 {
+				t.Errorf("healthy endpoint served %d requests, want 4", count.Load())
+			}
+		})
+	}
+}
+
+func TestEndpointsAllDown(t *testing.T) {
+
+	unavailable, _ := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	c := New(
+		WithEndpoints(Endpoint{URL: closedURL()}, Endpoint{URL: unavailable.URL}),
+		WithRetryPolicy(testPolicy),
+	)
+
+	_, err := c.GetNowBlock(context.Background())
+	if !errors.Is(err, ErrNoEndpoint) {
+		t.Fatalf("GetNowBlock error = %v, want ErrNoEndpoint", err)
+	}
+}
+
+func TestEndpointsNoV1(t *testing.T) {
+
+	c := New(WithEndpoints(Endpoint{URL: closedURL(), FullNodeOnly: true})).(*client)
+
+	req, err := http.NewRequest(http.MethodGet, "/v1/accounts/TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t/transactions", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.do(context.Background(), apiCall{op: "GetAccountTransactions", req: req, idempotent: true, v1: true})
+	if !errors.Is(err, ErrNoEndpoint) {
+		t.Fatalf("do error = %v, want ErrNoEndpoint", err)
+	}
+}
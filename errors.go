@@ -0,0 +1,187 @@
+package trongrid
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Response codes reported by full nodes in the "code" field of
+// broadcast and contract call responses.
+const (
+	CodeSuccess                      = "SUCCESS"
+	CodeSigError                     = "SIGERROR"
+	CodeContractValidateError        = "CONTRACT_VALIDATE_ERROR"
+	CodeContractExeError             = "CONTRACT_EXE_ERROR"
+	CodeBandwidthError               = "BANDWITH_ERROR"
+	CodeDupTransactionError          = "DUP_TRANSACTION_ERROR"
+	CodeTaposError                   = "TAPOS_ERROR"
+	CodeTooBigTransactionError       = "TOO_BIG_TRANSACTION_ERROR"
+	CodeTransactionExpirationError   = "TRANSACTION_EXPIRATION_ERROR"
+	CodeServerBusy                   = "SERVER_BUSY"
+	CodeNoConnection                 = "NO_CONNECTION"
+	CodeNotEnoughEffectiveConnection = "NOT_ENOUGH_EFFECTIVE_CONNECTION"
+	CodeBlockUnsolidified            = "BLOCK_UNSOLIDIFIED"
+	CodeOtherError                   = "OTHER_ERROR"
+)
+
+// APIError is returned by every Client method and cursor when TronGrid or
+// the full node rejects a request.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Endpoint is the URL the request was sent to.
+	Endpoint string
+	// Code is the node response code, e.g. SIGERROR, if one was reported.
+	Code string
+	// Message is the TronGrid "Error" or node "message" field.
+	Message string
+	// RetryAfter is the delay requested by the Retry-After header.
+	RetryAfter time.Duration
+
+	// Err is the sentinel the error matches with errors.Is, if any.
+	Err error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "trongrid: %s: status %d", e.Endpoint, e.StatusCode)
+
+	if e.Code != "" {
+		fmt.Fprintf(&b, ", code %s", e.Code)
+	}
+
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+
+	return b.String()
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// apiErrorBody covers the error shapes returned by both the full node
+// (/wallet) and TronGrid (/v1) APIs. JSON decoding is case-insensitive,
+// so Error matches both "Error" and "error".
+type apiErrorBody struct {
+	Error   string `json:"Error"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// newAPIError builds an APIError and classifies it against the sentinel
+// errors.
+func newAPIError(endpoint string, statusCode int, code, message string) *APIError {
+	e := &APIError{
+		StatusCode: statusCode,
+		Endpoint:   endpoint,
+		Code:       code,
+		Message:    message,
+	}
+
+	e.Err = classifyAPIError(e)
+
+	return e
+}
+
+func classifyAPIError(e *APIError) error {
+	switch e.Code {
+	case CodeSigError:
+		return ErrBadSignature
+	case CodeBandwidthError:
+		return ErrBandwidthExhausted
+	case CodeTransactionExpirationError:
+		return ErrTransactionExpired
+	}
+
+	switch e.StatusCode {
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusForbidden:
+		// TronGrid answers 403 once an API key exceeds its frequency limit.
+		if strings.Contains(strings.ToLower(e.Message), "frequency limit") {
+			return ErrRateLimited
+		}
+	}
+
+	return nil
+}
+
+// checkResponse returns an *APIError if the response has a non-2xx status,
+// its body carries a TronGrid "Error" field or a failed node response code.
+func checkResponse(endpoint string, statusCode int, body []byte) error {
+	var errBody apiErrorBody
+	_ = json.Unmarshal(body, &errBody)
+
+	if statusCode < 200 || statusCode > 299 {
+		message := errBody.Error
+		if message == "" {
+			message = errBody.Message
+		}
+
+		if message == "" && !json.Valid(body) {
+			message = strings.TrimSpace(string(body))
+		}
+
+		return newAPIError(endpoint, statusCode, errBody.Code, message)
+	}
+
+	if errBody.Error != "" {
+		return newAPIError(endpoint, statusCode, errBody.Code, errBody.Error)
+	}
+
+	if errBody.Code != "" && errBody.Code != CodeSuccess {
+		return newAPIError(endpoint, statusCode, errBody.Code, decodeMessage(errBody.Message))
+	}
+
+	return nil
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or
+// as an HTTP date.
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if t, err := http.ParseTime(value); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// decodeMessage returns the text of a node message, which full nodes
+// hex-encode in broadcast and contract call responses and in resMessage.
+// Messages that do not decode to printable UTF-8 are returned as is.
+func decodeMessage(message string) string {
+	b, err := hex.DecodeString(message)
+	if err != nil || len(b) == 0 || !utf8.Valid(b) {
+		return message
+	}
+
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return message
+		}
+	}
+
+	return string(b)
+}
@@ -0,0 +1,185 @@
+// Package events decodes the logs of TransactionInfo into named, typed
+// events using contract ABIs.
+package events
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/abi"
+)
+
+var ErrUnknownEvent = errors.New("unknown event")
+
+// Event is a decoded log.
+type Event struct {
+	Name string
+	// Signature is the event signature, e.g.
+	// "Transfer(address,address,uint256)".
+	Signature string
+	// Contract is the address of the contract that emitted the event.
+	Contract trongrid.Address
+	// Values are the arguments in declaration order, decoded as by the
+	// abi package. Indexed arguments of dynamic types are only available
+	// as the hash stored in their topic.
+	Values []interface{}
+	// Args are the same values by argument name.
+	Args map[string]interface{}
+}
+
+// Decoder decodes logs of the events it knows. Events that share a
+// signature but differ in indexed arguments, such as the TRC20 and TRC721
+// Transfer events, are told apart by the number of topics.
+type Decoder struct {
+	events map[string][]*abi.Event
+}
+
+// NewDecoder returns a decoder for the events of abis.
+func NewDecoder(abis ...*abi.ABI) *Decoder {
+
+	d := &Decoder{events: make(map[string][]*abi.Event)}
+
+	for _, a := range abis {
+		d.Add(a)
+	}
+
+	return d
+}
+
+// Add adds the events of a. Anonymous events cannot be identified from
+// their logs and are skipped.
+func (d *Decoder) Add(a *abi.ABI) {
+	for _, event := range a.Events {
+		d.AddEvent(event)
+	}
+}
+
+func (d *Decoder) AddEvent(event *abi.Event) {
+
+	if event.Anonymous {
+		return
+	}
+
+	id := hex.EncodeToString(event.ID())
+
+	for _, known := range d.events[id] {
+		if indexedCount(known) == indexedCount(event) {
+			return
+		}
+	}
+
+	d.events[id] = append(d.events[id], event)
+}
+
+// Decode decodes log. Logs of events the decoder does not know return an
+// error wrapping ErrUnknownEvent.
+func (d *Decoder) Decode(log trongrid.Log) (*Event, error) {
+
+	if len(log.Topics) == 0 {
+		return nil, fmt.Errorf("%w: anonymous log", ErrUnknownEvent)
+	}
+
+	for _, event := range d.events[strings.ToLower(strings.TrimPrefix(log.Topics[0], "0x"))] {
+		if indexedCount(event)+1 == len(log.Topics) {
+			return decodeLog(event, log)
+		}
+	}
+
+	return nil, fmt.Errorf("%w: topic %s with %d topics", ErrUnknownEvent, log.Topics[0], len(log.Topics))
+}
+
+// DecodeLogs decodes the logs the decoder knows, typically the Log of a
+// GetTransactionInfoByIDResponse, and skips the others.
+func (d *Decoder) DecodeLogs(logs []trongrid.Log) ([]*Event, error) {
+
+	var events []*Event
+
+	for _, log := range logs {
+		event, err := d.Decode(log)
+		if errors.Is(err, ErrUnknownEvent) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+func decodeLog(event *abi.Event, log trongrid.Log) (*Event, error) {
+
+	data, err := hex.DecodeString(strings.TrimPrefix(log.Data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid data: %w", event.Name, err)
+	}
+
+	nonIndexed, err := event.Inputs.NonIndexed().Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", event.Name, err)
+	}
+
+	decoded := &Event{
+		Name:      event.Name,
+		Signature: event.Signature(),
+		Contract:  log.Address,
+		Values:    make([]interface{}, len(event.Inputs)),
+		Args:      make(map[string]interface{}, len(event.Inputs)),
+	}
+
+	topics := log.Topics[1:]
+
+	for i, input := range event.Inputs {
+		var value interface{}
+
+		if input.Indexed {
+			value, err = decodeTopic(input, topics[0])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", event.Name, input.Name, err)
+			}
+
+			topics = topics[1:]
+		} else {
+			value, nonIndexed = nonIndexed[0], nonIndexed[1:]
+		}
+
+		decoded.Values[i] = value
+		if input.Name != "" {
+			decoded.Args[input.Name] = value
+		}
+	}
+
+	return decoded, nil
+}
+
+// decodeTopic decodes an indexed argument. Values of dynamic types and
+// of arrays and tuples are hashed into the topic, which is returned as is.
+func decodeTopic(input abi.Argument, topic string) (interface{}, error) {
+
+	b, err := hex.DecodeString(strings.TrimPrefix(topic, "0x"))
+	if err != nil || len(b) != 32 {
+		return nil, fmt.Errorf("invalid topic %q", topic)
+	}
+
+	switch input.Type.Kind {
+	case abi.KindString, abi.KindBytes, abi.KindSlice, abi.KindArray, abi.KindTuple:
+		return b, nil
+	}
+
+	values, err := abi.Arguments{input}.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0], nil
+}
+
+func indexedCount(event *abi.Event) int {
+	return len(event.Inputs) - len(event.Inputs.NonIndexed())
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/abi"
+	"github.com/TheTeaParty/trongrid/trc20"
+)
+
+// TRC721ABI holds the events of the TRC721 standard.
+var TRC721ABI = abi.MustParse(`[
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]},
+	{"type":"event","name":"Approval","inputs":[{"name":"owner","type":"address","indexed":true},{"name":"approved","type":"address","indexed":true},{"name":"tokenId","type":"uint256","indexed":true}]},
+	{"type":"event","name":"ApprovalForAll","inputs":[{"name":"owner","type":"address","indexed":true},{"name":"operator","type":"address","indexed":true},{"name":"approved","type":"bool","indexed":false}]}
+]`)
+
+// Standard returns a decoder for the TRC20 and TRC721 events. More ABIs
+// can be added to it.
+func Standard() *Decoder {
+	return NewDecoder(trc20.ABI, TRC721ABI)
+}
+
+var standard = Standard()
+
+type TRC20Transfer struct {
+	Token trongrid.Address
+	From  trongrid.Address
+	To    trongrid.Address
+	Value *big.Int
+}
+
+type TRC20Approval struct {
+	Token   trongrid.Address
+	Owner   trongrid.Address
+	Spender trongrid.Address
+	Value   *big.Int
+}
+
+type TRC721Transfer struct {
+	Token   trongrid.Address
+	From    trongrid.Address
+	To      trongrid.Address
+	TokenID *big.Int
+}
+
+// DecodeStandard decodes a TRC20 Transfer or Approval or a TRC721
+// Transfer log into a *TRC20Transfer, *TRC20Approval or *TRC721Transfer.
+// Other logs return an error wrapping ErrUnknownEvent.
+func DecodeStandard(log trongrid.Log) (interface{}, error) {
+
+	event, err := standard.Decode(log)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case event.Name == "Transfer" && len(log.Topics) == 3:
+		return &TRC20Transfer{
+			Token: event.Contract,
+			From:  event.Values[0].(trongrid.Address),
+			To:    event.Values[1].(trongrid.Address),
+			Value: event.Values[2].(*big.Int),
+		}, nil
+
+	case event.Name == "Approval" && len(log.Topics) == 3:
+		return &TRC20Approval{
+			Token:   event.Contract,
+			Owner:   event.Values[0].(trongrid.Address),
+			Spender: event.Values[1].(trongrid.Address),
+			Value:   event.Values[2].(*big.Int),
+		}, nil
+
+	case event.Name == "Transfer" && len(log.Topics) == 4:
+		return &TRC721Transfer{
+			Token:   event.Contract,
+			From:    event.Values[0].(trongrid.Address),
+			To:      event.Values[1].(trongrid.Address),
+			TokenID: event.Values[2].(*big.Int),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, event.Signature)
+}
+
+// TRC20Transfers returns the TRC20 transfers among logs, typically the
+// Log of a GetTransactionInfoByIDResponse.
+func TRC20Transfers(logs []trongrid.Log) []*TRC20Transfer {
+
+	var transfers []*TRC20Transfer
+
+	for _, log := range logs {
+		event, err := DecodeStandard(log)
+		if err != nil {
+			continue
+		}
+
+		if transfer, ok := event.(*TRC20Transfer); ok {
+			transfers = append(transfers, transfer)
+		}
+	}
+
+	return transfers
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/TheTeaParty/trongrid"
+)
+
+var (
+	usdt  = trongrid.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	owner = trongrid.MustParseAddress("TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL")
+	zero  = trongrid.MustParseAddress("T9yD14Nj9j7xAB4dbGeiX9h8unkKHxuWwb")
+)
+
+// Logs as returned in the log of gettransactioninfobyid.
+const (
+	// 1 USDT from TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL to the USDT contract.
+	usdtTransferLog = `{
+		"address": "a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"topics": [
+			"ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0000000000000000000000008840e6c55b9ada326d211d818c34a994aeced808",
+			"000000000000000000000000a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+		],
+		"data": "00000000000000000000000000000000000000000000000000000000000f4240"
+	}`
+
+	// Mint of token 7 to TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL.
+	trc721TransferLog = `{
+		"address": "8840e6c55b9ada326d211d818c34a994aeced808",
+		"topics": [
+			"ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0000000000000000000000000000000000000000000000000000000000000000",
+			"0000000000000000000000008840e6c55b9ada326d211d818c34a994aeced808",
+			"0000000000000000000000000000000000000000000000000000000000000007"
+		],
+		"data": ""
+	}`
+
+	// OwnershipTransferred(address,address) of Ownable.
+	unknownLog = `{
+		"address": "a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+		"topics": [
+			"8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0",
+			"0000000000000000000000008840e6c55b9ada326d211d818c34a994aeced808",
+			"0000000000000000000000000000000000000000000000000000000000000000"
+		],
+		"data": ""
+	}`
+)
+
+func parseLog(t *testing.T, s string) trongrid.Log {
+	t.Helper()
+
+	var log trongrid.Log
+	err := json.Unmarshal([]byte(s), &log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return log
+}
+
+func TestDecodeStandard(t *testing.T) {
+
+	tests := []struct {
+		name string
+		log  string
+		want interface{}
+	}{
+		{
+			name: "TRC20 transfer",
+			log:  usdtTransferLog,
+			want: &TRC20Transfer{Token: usdt, From: owner, To: usdt, Value: big.NewInt(1000000)},
+		},
+		{
+			name: "TRC721 transfer",
+			log:  trc721TransferLog,
+			want: &TRC721Transfer{Token: owner, From: zero, To: owner, TokenID: big.NewInt(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			event, err := DecodeStandard(parseLog(t, tt.log))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(event, tt.want) {
+				t.Errorf("DecodeStandard = %+v, want %+v", event, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeStandardUnknown(t *testing.T) {
+
+	noTopics := parseLog(t, usdtTransferLog)
+	noTopics.Topics = nil
+
+	// A Transfer with only the signature topic matches neither standard.
+	oneTopic := parseLog(t, usdtTransferLog)
+	oneTopic.Topics = oneTopic.Topics[:1]
+
+	for name, log := range map[string]trongrid.Log{
+		"unknown signature": parseLog(t, unknownLog),
+		"no topics":         noTopics,
+		"topic count":       oneTopic,
+	} {
+		_, err := DecodeStandard(log)
+		if !errors.Is(err, ErrUnknownEvent) {
+			t.Errorf("%s: DecodeStandard error = %v, want ErrUnknownEvent", name, err)
+		}
+	}
+}
+
+func TestTRC20Transfers(t *testing.T) {
+
+	logs := []trongrid.Log{
+		parseLog(t, unknownLog),
+		parseLog(t, usdtTransferLog),
+		parseLog(t, trc721TransferLog),
+	}
+
+	transfers := TRC20Transfers(logs)
+
+	want := []*TRC20Transfer{{Token: usdt, From: owner, To: usdt, Value: big.NewInt(1000000)}}
+	if !reflect.DeepEqual(transfers, want) {
+		t.Errorf("TRC20Transfers = %+v, want %+v", transfers, want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -78,58 +79,135 @@ func WithSearchInternal(v bool) GetAccountTransactionsOption {
 }
 
 type Transaction struct {
-	TxID           string `json:"txID"`
-	BlockNumber    int    `json:"blockNumber"`
-	BlockTimestamp int64  `json:"block_timestamp"`
-	Ret            []struct {
-		ContractRet string `json:"contractRet"`
-		Fee         int    `json:"fee"`
-	} `json:"ret"`
-	Signature  []string `json:"signature"`
-	RawDataHex string   `json:"raw_data_hex"`
-	RawData    struct {
-		Contract []struct {
-			Parameter struct {
-				Value struct {
-					OwnerAddress    string `json:"owner_address"`
-					ToAddress       string `json:"to_address,omitempty"`
-					Amount          int64  `json:"amount,omitempty"`
-					UnfreezeBalance int    `json:"unfreeze_balance,omitempty"`
-					Resource        string `json:"resource,omitempty"`
-					Balance         int64  `json:"balance,omitempty"`
-					ReceiverAddress string `json:"receiver_address,omitempty"`
-					Lock            bool   `json:"lock,omitempty"`
-					LockPeriod      int    `json:"lock_period,omitempty"`
-					FrozenBalance   int64  `json:"frozen_balance,omitempty"`
-				} `json:"value"`
-				TypeUrl string `json:"type_url"`
-			} `json:"parameter"`
-			Type string `json:"type"`
-		} `json:"contract"`
-		RefBlockBytes string `json:"ref_block_bytes"`
-		RefBlockHash  string `json:"ref_block_hash"`
-		Expiration    int64  `json:"expiration"`
-		Timestamp     int64  `json:"timestamp"`
-	} `json:"raw_data"`
-	EnergyFee            int           `json:"energy_fee"`
-	EnergyUsage          int           `json:"energy_usage"`
-	EnergyUsageTotal     int           `json:"energy_usage_total"`
-	NetFee               int           `json:"net_fee"`
-	NetUsage             int           `json:"net_usage"`
-	InternalTransactions []interface{} `json:"internal_transactions"`
+	TxID                 string             `json:"txID"`
+	BlockNumber          int                `json:"blockNumber"`
+	BlockTimestamp       int64              `json:"block_timestamp"`
+	Ret                  []TransactionRet   `json:"ret"`
+	Signature            []string           `json:"signature"`
+	RawDataHex           string             `json:"raw_data_hex"`
+	RawData              TransactionRawData `json:"raw_data"`
+	EnergyFee            Sun                `json:"energy_fee"`
+	EnergyUsage          int                `json:"energy_usage"`
+	EnergyUsageTotal     int                `json:"energy_usage_total"`
+	NetFee               Sun                `json:"net_fee"`
+	NetUsage             int                `json:"net_usage"`
+	InternalTransactions []interface{}      `json:"internal_transactions"`
+}
+
+type TransactionRet struct {
+	ContractRet string `json:"contractRet"`
+	Fee         Sun    `json:"fee"`
+}
+
+type TransactionRawData struct {
+	Contract      []TransactionContract `json:"contract"`
+	RefBlockBytes string                `json:"ref_block_bytes"`
+	RefBlockHash  string                `json:"ref_block_hash"`
+	Expiration    int64                 `json:"expiration"`
+	Timestamp     int64                 `json:"timestamp"`
+	FeeLimit      Sun                   `json:"fee_limit,omitempty"`
+	Data          string                `json:"data,omitempty"`
+}
+
+// TransactionContract is a contract of a transaction. Type is the contract
+// type, e.g. "TransferContract" or "TriggerSmartContract".
+type TransactionContract struct {
+	Parameter struct {
+		Value   ContractValue `json:"value"`
+		TypeUrl string        `json:"type_url"`
+	} `json:"parameter"`
+	Type         string `json:"type"`
+	PermissionId int    `json:"Permission_id,omitempty"`
+}
+
+// ContractValue holds the parameters of the common contract types. Only
+// the fields of the contract's type are set.
+//
+// Contract types disagree on the type of some fields: a field whose value
+// does not fit its type here is left unset rather than failing the whole
+// transaction.
+type ContractValue struct {
+	OwnerAddress    Address `json:"owner_address"`
+	ToAddress       Address `json:"to_address"`
+	Amount          Sun     `json:"amount,omitempty"`
+	AssetName       string  `json:"asset_name,omitempty"`
+	ContractAddress Address `json:"contract_address"`
+	Data            string  `json:"data,omitempty"`
+	CallValue       Sun     `json:"call_value,omitempty"`
+	CallTokenValue  int64   `json:"call_token_value,omitempty"`
+	TokenId         TokenID `json:"token_id,omitempty"`
+	UnfreezeBalance Sun     `json:"unfreeze_balance,omitempty"`
+	Resource        string  `json:"resource,omitempty"`
+	Balance         Sun     `json:"balance,omitempty"`
+	ReceiverAddress Address `json:"receiver_address"`
+	Lock            bool    `json:"lock,omitempty"`
+	LockPeriod      int     `json:"lock_period,omitempty"`
+	FrozenBalance   Sun     `json:"frozen_balance,omitempty"`
+}
+
+func (v *ContractValue) UnmarshalJSON(b []byte) error {
+
+	type value ContractValue
+
+	err := json.Unmarshal(b, (*value)(v))
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return nil
+	}
+
+	return err
+}
+
+// TokenID is the id of a TRC10 token. Smart contract calls carry it as a
+// number and exchange contracts as bytes, so it is kept as text: the
+// decimal id or the encoded bytes as returned by the node.
+type TokenID string
+
+func (t *TokenID) UnmarshalJSON(b []byte) error {
+
+	if string(b) == "null" {
+		return nil
+	}
+
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		err := json.Unmarshal(b, &s)
+		if err != nil {
+			return err
+		}
+
+		*t = TokenID(s)
+		return nil
+	}
+
+	var n json.Number
+	err := json.Unmarshal(b, &n)
+	if err != nil {
+		return fmt.Errorf("invalid token id %s", b)
+	}
+
+	*t = TokenID(n)
+
+	return nil
 }
 
 type GetAccountTransactionsCursor struct {
 	options *GetAccountTransactionsOptions
 	client  *client
 
-	currentURL   string
-	err          error
-	data         []*Transaction
-	currentIndex int
+	startURL        string
+	currentURL      string
+	pageFingerprint string
+	meta            Meta
+	endpoint        *endpointState
+	page            int
+	err             error
+	data            []*Transaction
+	currentIndex    int
 }
 
-func (c *client) GetAccountTransactions(ctx context.Context, address string,
+func (c *client) GetAccountTransactions(ctx context.Context, address Address,
 	opts ...GetAccountTransactionsOption) (*GetAccountTransactionsCursor, error) {
 
 	options := &GetAccountTransactionsOptions{}
@@ -138,7 +216,7 @@ func (c *client) GetAccountTransactions(ctx context.Context, address string,
 		opt(options)
 	}
 
-	urlStr := fmt.Sprintf("%s/v1/accounts/%s/transactions", c.options.baseURL, address)
+	urlStr := fmt.Sprintf("%s/v1/accounts/%s/transactions", c.baseURL(true), address)
 
 	u, err := url.Parse(urlStr)
 	if err != nil {
@@ -189,6 +267,7 @@ func (c *client) GetAccountTransactions(ctx context.Context, address string,
 		options: options,
 		client:  c,
 
+		startURL:     u.String(),
 		currentURL:   u.String(),
 		data:         make([]*Transaction, 0),
 		currentIndex: 0,
@@ -228,13 +307,7 @@ func (c *GetAccountTransactionsCursor) Next(ctx context.Context) bool {
 		return false
 	}
 
-	if c.client.options.rateLimiter != nil {
-		err := c.client.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			c.err = err
-			return false
-		}
-	}
+	c.pageFingerprint = fingerprintOf(c.currentURL)
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.currentURL, nil)
 	if err != nil {
@@ -242,24 +315,28 @@ func (c *GetAccountTransactionsCursor) Next(ctx context.Context) bool {
 		return false
 	}
 
-	response, err := c.client.options.httpClient.Do(request)
+	c.page++
+
+	call := apiCall{op: "GetAccountTransactions", page: c.page, req: request, idempotent: true, v1: true}
+	if c.client.options.stickyCursors {
+		call.sticky = &c.endpoint
+	}
+
+	body, err := c.client.do(ctx, call)
 	if err != nil {
 		c.err = err
+		return false
 	}
 
-	defer func() {
-		_ = response.Body.Close()
-	}()
-
 	var responseData GetAccountTransactionsResponse
-	err = json.NewDecoder(response.Body).Decode(&responseData)
+	err = c.client.decode(ctx, "GetAccountTransactions", body, &responseData)
 	if err != nil {
 		c.err = err
 		return false
 	}
 
 	if responseData.Success == false {
-		c.err = errors.New("failed to get account transaction")
+		c.err = newAPIError(c.currentURL, http.StatusOK, "", "failed to get account transaction")
 		return false
 	}
 
@@ -268,21 +345,103 @@ func (c *GetAccountTransactionsCursor) Next(ctx context.Context) bool {
 	}
 
 	c.data = responseData.Data
+	c.meta = responseData.Meta
 	c.currentURL = responseData.Meta.Links.Next
 	c.currentIndex = 0
 
 	return true
 }
 
+// All returns an iterator over the remaining items of the cursor. A
+// failure is yielded once as the last element with a nil item.
+func (c *GetAccountTransactionsCursor) All(ctx context.Context) iter.Seq2[*Transaction, error] {
+	return func(yield func(*Transaction, error) bool) {
+		for c.Next(ctx) {
+			item := c.data[c.currentIndex]
+			c.currentIndex++
+
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if c.err != nil {
+			yield(nil, c.err)
+		}
+	}
+}
+
+// Pages returns an iterator over the remaining pages of the cursor. Items
+// of the current page that were not read yet are yielded as a first,
+// partial page.
+func (c *GetAccountTransactionsCursor) Pages(ctx context.Context) iter.Seq2[[]*Transaction, error] {
+	return func(yield func([]*Transaction, error) bool) {
+		for c.Next(ctx) {
+			page := c.data[c.currentIndex:]
+			c.currentIndex = len(c.data)
+
+			if !yield(page, nil) {
+				return
+			}
+		}
+
+		if c.err != nil {
+			yield(nil, c.err)
+		}
+	}
+}
+
+// Err returns the error that stopped the cursor, if any.
+func (c *GetAccountTransactionsCursor) Err() error {
+	return c.err
+}
+
+// Meta returns the metadata of the last fetched page.
+func (c *GetAccountTransactionsCursor) Meta() Meta {
+	return c.meta
+}
+
+// Fingerprint returns the fingerprint to persist in order to continue the
+// scan later with Resume. While items of the current page are still
+// unread it points at the current page, so resuming returns those items
+// again rather than skipping them. It is empty before the first page and
+// once the last page has been read.
+func (c *GetAccountTransactionsCursor) Fingerprint() string {
+	if c.currentIndex < len(c.data) {
+		return c.pageFingerprint
+	}
+
+	if c.currentURL == "" {
+		return ""
+	}
+
+	return fingerprintOf(c.currentURL)
+}
+
+// Resume returns a new cursor with the same address and options that
+// starts at the page identified by fingerprint, as returned by
+// Fingerprint or Meta. The receiver is not modified.
+func (c *GetAccountTransactionsCursor) Resume(fingerprint string) *GetAccountTransactionsCursor {
+	resumed := *c
+
+	resumed.currentURL = withFingerprint(c.startURL, fingerprint)
+	resumed.pageFingerprint = ""
+	resumed.meta = Meta{}
+	resumed.endpoint = nil
+	resumed.page = 0
+	resumed.err = nil
+	resumed.data = make([]*Transaction, 0)
+	resumed.currentIndex = 0
+
+	return &resumed
+}
+
 type GetAccountTransactionsResponse struct {
 	Data    []*Transaction `json:"data"`
 	Success bool           `json:"success"`
-	Meta    struct {
-		PageSize    int    `json:"page_size"`
-		At          int64  `json:"at"`
-		Fingerprint string `json:"fingerprint"`
-		Links       struct {
-			Next string `json:"next"`
-		} `json:"links"`
-	} `json:"meta"`
+	Meta    Meta           `json:"meta"`
+}
+
+func (t *Transaction) GetBlockTimestamp() int64 {
+	return t.BlockTimestamp
 }
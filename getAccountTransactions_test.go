@@ -0,0 +1,47 @@
+package trongrid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestContractValueUnmarshal(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		in      string
+		tokenID TokenID
+		amount  Sun
+	}{
+		{name: "trigger", in: `{"token_id":1002000,"call_token_value":5}`, tokenID: "1002000"},
+		{name: "exchange", in: `{"token_id":"31303032303030","quant":10}`, tokenID: "31303032303030"},
+		{name: "missing", in: `{"amount":1000000}`, amount: 1_000_000},
+		{name: "null", in: `{"token_id":null}`},
+		// A field of another type is skipped, the others are kept.
+		{name: "mismatch", in: `{"amount":"all","token_id":7}`, tokenID: "7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			var v ContractValue
+			err := json.Unmarshal([]byte(tt.in), &v)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v.TokenId != tt.tokenID || v.Amount != tt.amount {
+				t.Errorf("got token id %q, amount %d, want %q, %d", v.TokenId, v.Amount, tt.tokenID, tt.amount)
+			}
+		})
+	}
+
+	var v ContractValue
+	if json.Unmarshal([]byte(`{"token_id":true}`), &v) == nil {
+		t.Error("Unmarshal accepted a boolean token id")
+	}
+
+	if json.Unmarshal([]byte(`{"owner_address":"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u"}`), &v) == nil {
+		t.Error("Unmarshal accepted an invalid address")
+	}
+}
@@ -0,0 +1,18 @@
+package trongrid
+
+import "encoding/json"
+
+// SmartContract is a deployed contract as returned by /wallet/getcontract.
+// ABI is kept raw; it can be parsed with abi.Parse.
+type SmartContract struct {
+	OriginAddress              Address         `json:"origin_address"`
+	ContractAddress            Address         `json:"contract_address"`
+	ABI                        json.RawMessage `json:"abi"`
+	Bytecode                   string          `json:"bytecode"`
+	CallValue                  Sun             `json:"call_value"`
+	ConsumeUserResourcePercent int             `json:"consume_user_resource_percent"`
+	Name                       string          `json:"name"`
+	OriginEnergyLimit          int64           `json:"origin_energy_limit"`
+	CodeHash                   string          `json:"code_hash"`
+	Version                    int             `json:"version"`
+}
@@ -3,8 +3,8 @@ package trongrid
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 )
@@ -17,7 +17,7 @@ type GetContractTransactionOptions struct {
 	orderBy         *string
 	minTimestamp    *int64
 	maxTimestamp    *int64
-	contractAddress *string
+	contractAddress *Address
 	onlyTo          *bool
 	onlyFrom        *bool
 }
@@ -66,7 +66,7 @@ func WithContractTransactionMaxTimestamp(maxTimestamp int64) GetContractTransact
 	}
 }
 
-func WithContractTransactionContractAddress(contractAddress string) GetContractTransactionOption {
+func WithContractTransactionContractAddress(contractAddress Address) GetContractTransactionOption {
 	return func(o *GetContractTransactionOptions) {
 		o.contractAddress = &contractAddress
 	}
@@ -86,23 +86,21 @@ func WithContractTransactionOnlyFrom(onlyFrom bool) GetContractTransactionOption
 
 type GetContractTransactionCursor struct {
 	contractType string
-	address      string
-	options      *clientOptions
-
-	currentURL   string
-	err          error
-	data         []*ContractTransaction
-	currentIndex int
+	address      Address
+	client       *client
+
+	startURL        string
+	currentURL      string
+	pageFingerprint string
+	meta            Meta
+	endpoint        *endpointState
+	page            int
+	err             error
+	data            []*ContractTransaction
+	currentIndex    int
 }
 
-func (c *client) GetContractTransaction(ctx context.Context, address, contractType string, opts ...GetContractTransactionOption) (*GetContractTransactionCursor, error) {
-
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			return nil, err
-		}
-	}
+func (c *client) GetContractTransaction(ctx context.Context, address Address, contractType string, opts ...GetContractTransactionOption) (*GetContractTransactionCursor, error) {
 
 	options := &GetContractTransactionOptions{}
 
@@ -110,7 +108,7 @@ func (c *client) GetContractTransaction(ctx context.Context, address, contractTy
 		opt(options)
 	}
 
-	fullURLStr := fmt.Sprintf("%s/v1/accounts/%s/transactions/%s", c.options.baseURL,
+	fullURLStr := fmt.Sprintf("%s/v1/accounts/%s/transactions/%s", c.baseURL(true),
 		address, contractType)
 
 	u, err := url.Parse(fullURLStr)
@@ -149,7 +147,7 @@ func (c *client) GetContractTransaction(ctx context.Context, address, contractTy
 	}
 
 	if options.contractAddress != nil {
-		q.Set("contract_address", *options.contractAddress)
+		q.Set("contract_address", options.contractAddress.String())
 	}
 
 	if options.onlyTo != nil {
@@ -165,8 +163,9 @@ func (c *client) GetContractTransaction(ctx context.Context, address, contractTy
 	cursor := &GetContractTransactionCursor{
 		contractType: contractType,
 		address:      address,
-		options:      c.options,
+		client:       c,
 
+		startURL:     u.String(),
 		currentURL:   u.String(),
 		err:          nil,
 		currentIndex: 0,
@@ -190,13 +189,7 @@ func (c *GetContractTransactionCursor) Next(ctx context.Context) bool {
 		return false
 	}
 
-	if c.options.rateLimiter != nil {
-		err := c.options.rateLimiter.Wait(ctx)
-		if err != nil {
-			c.err = err
-			return false
-		}
-	}
+	c.pageFingerprint = fingerprintOf(c.currentURL)
 
 	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.currentURL, nil)
 	if err != nil {
@@ -204,24 +197,28 @@ func (c *GetContractTransactionCursor) Next(ctx context.Context) bool {
 		return false
 	}
 
-	response, err := c.options.httpClient.Do(request)
+	c.page++
+
+	call := apiCall{op: "GetContractTransaction", page: c.page, req: request, idempotent: true, v1: true}
+	if c.client.options.stickyCursors {
+		call.sticky = &c.endpoint
+	}
+
+	body, err := c.client.do(ctx, call)
 	if err != nil {
 		c.err = err
+		return false
 	}
 
-	defer func() {
-		_ = response.Body.Close()
-	}()
-
 	var responseData GetContractTransactionResponse
-	err = json.NewDecoder(response.Body).Decode(&responseData)
+	err = c.client.decode(ctx, "GetContractTransaction", body, &responseData)
 	if err != nil {
 		c.err = err
 		return false
 	}
 
 	if responseData.Success == false {
-		c.err = errors.New("failed to get contract transaction")
+		c.err = newAPIError(c.currentURL, http.StatusOK, "", "failed to get contract transaction")
 		return false
 	}
 
@@ -230,12 +227,97 @@ func (c *GetContractTransactionCursor) Next(ctx context.Context) bool {
 	}
 
 	c.data = responseData.Data
+	c.meta = responseData.Meta
 	c.currentURL = responseData.Meta.Links.Next
 	c.currentIndex = 0
 
 	return true
 }
 
+// All returns an iterator over the remaining items of the cursor. A
+// failure is yielded once as the last element with a nil item.
+func (c *GetContractTransactionCursor) All(ctx context.Context) iter.Seq2[*ContractTransaction, error] {
+	return func(yield func(*ContractTransaction, error) bool) {
+		for c.Next(ctx) {
+			item := c.data[c.currentIndex]
+			c.currentIndex++
+
+			if !yield(item, nil) {
+				return
+			}
+		}
+
+		if c.err != nil {
+			yield(nil, c.err)
+		}
+	}
+}
+
+// Pages returns an iterator over the remaining pages of the cursor. Items
+// of the current page that were not read yet are yielded as a first,
+// partial page.
+func (c *GetContractTransactionCursor) Pages(ctx context.Context) iter.Seq2[[]*ContractTransaction, error] {
+	return func(yield func([]*ContractTransaction, error) bool) {
+		for c.Next(ctx) {
+			page := c.data[c.currentIndex:]
+			c.currentIndex = len(c.data)
+
+			if !yield(page, nil) {
+				return
+			}
+		}
+
+		if c.err != nil {
+			yield(nil, c.err)
+		}
+	}
+}
+
+// Err returns the error that stopped the cursor, if any.
+func (c *GetContractTransactionCursor) Err() error {
+	return c.err
+}
+
+// Meta returns the metadata of the last fetched page.
+func (c *GetContractTransactionCursor) Meta() Meta {
+	return c.meta
+}
+
+// Fingerprint returns the fingerprint to persist in order to continue the
+// scan later with Resume. While items of the current page are still
+// unread it points at the current page, so resuming returns those items
+// again rather than skipping them. It is empty before the first page and
+// once the last page has been read.
+func (c *GetContractTransactionCursor) Fingerprint() string {
+	if c.currentIndex < len(c.data) {
+		return c.pageFingerprint
+	}
+
+	if c.currentURL == "" {
+		return ""
+	}
+
+	return fingerprintOf(c.currentURL)
+}
+
+// Resume returns a new cursor with the same address and options that
+// starts at the page identified by fingerprint, as returned by
+// Fingerprint or Meta. The receiver is not modified.
+func (c *GetContractTransactionCursor) Resume(fingerprint string) *GetContractTransactionCursor {
+	resumed := *c
+
+	resumed.currentURL = withFingerprint(c.startURL, fingerprint)
+	resumed.pageFingerprint = ""
+	resumed.meta = Meta{}
+	resumed.endpoint = nil
+	resumed.page = 0
+	resumed.err = nil
+	resumed.data = make([]*ContractTransaction, 0)
+	resumed.currentIndex = 0
+
+	return &resumed
+}
+
 func (c *GetContractTransactionCursor) Current() (*ContractTransaction, error) {
 	if c.err != nil {
 		return nil, c.err
@@ -262,19 +344,39 @@ type ContractTransaction struct {
 	TransactionId  string                    `json:"transaction_id"`
 	TokenInfo      *ContractTransactionToken `json:"token_info"`
 	BlockTimestamp int64                     `json:"block_timestamp"`
-	From           string                    `json:"from"`
-	To             string                    `json:"to"`
+	From           Address                   `json:"from"`
+	To             Address                   `json:"to"`
 	Type           string                    `json:"type"`
-	Value          string                    `json:"value"`
+	Value          Amount                    `json:"value"`
+}
+
+// UnmarshalJSON decodes the transaction and sets the decimals of Value
+// from TokenInfo.
+func (t *ContractTransaction) UnmarshalJSON(b []byte) error {
+
+	type contractTransaction ContractTransaction
+
+	err := json.Unmarshal(b, (*contractTransaction)(t))
+	if err != nil {
+		return err
+	}
+
+	if t.TokenInfo != nil {
+		t.Value = t.Value.WithDecimals(t.TokenInfo.Decimals)
+	}
+
+	return nil
 }
 
 type ContractTransactionToken struct {
-	Symbol   string `json:"symbol"`
-	Address  string `json:"address"`
-	Decimals int    `json:"decimals"`
-	Name     string `json:"name"`
+	Symbol   string  `json:"symbol"`
+	Address  Address `json:"address"`
+	Decimals int     `json:"decimals"`
+	Name     string  `json:"name"`
 }
 
+// Meta is the pagination metadata of a TronGrid /v1 page. Fingerprint
+// identifies the next page.
 type Meta struct {
 	At          int64  `json:"at"`
 	Fingerprint string `json:"fingerprint"`
@@ -283,3 +385,7 @@ type Meta struct {
 	} `json:"links"`
 	PageSize int `json:"page_size"`
 }
+
+func (t *ContractTransaction) GetBlockTimestamp() int64 {
+	return t.BlockTimestamp
+}
@@ -0,0 +1,26 @@
+package trongrid
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetContractTransactionURL(t *testing.T) {
+
+	c := New(WithNetwork(NetworkMainnet))
+
+	owner := MustParseAddress("41928c9af0651632157ef27a2cf17ca72c575a4d21")
+	usdt := MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+	cursor, err := c.GetContractTransaction(context.Background(), owner, "trc20",
+		WithContractTransactionContractAddress(usdt),
+		WithContractTransactionLimit(50))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "https://api.trongrid.io/v1/accounts/TPL66VK2gCXNCD7EJg9pgJRfqcRazjhUZY/transactions/trc20?contract_address=TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t&limit=50"
+	if cursor.startURL != want {
+		t.Errorf("URL = %s, want %s", cursor.startURL, want)
+	}
+}
@@ -1,21 +1,164 @@
 package trongrid
 
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// GetTransactionInfoByIDResponse mirrors core.TransactionInfo. Fields
+// that do not apply to the transaction are left zero.
 type GetTransactionInfoByIDResponse struct {
-	Id             string                                `json:"id"`
-	Fee            int                                   `json:"fee"`
-	BlockNumber    int                                   `json:"blockNumber"`
-	BlockTimeStamp int64                                 `json:"blockTimeStamp"`
-	ContractResult []string                              `json:"contractResult"`
-	Receipt        GetTransactionInfoByIDResponseReceipt `json:"receipt"`
+	Id                            string                                `json:"id"`
+	Fee                           Sun                                   `json:"fee"`
+	BlockNumber                   int                                   `json:"blockNumber"`
+	BlockTimeStamp                int64                                 `json:"blockTimeStamp"`
+	ContractResult                []string                              `json:"contractResult"`
+	ContractAddress               Address                               `json:"contract_address"`
+	Receipt                       GetTransactionInfoByIDResponseReceipt `json:"receipt"`
+	Log                           []Log                                 `json:"log"`
+	Result                        TransactionInfoResult                 `json:"result"`
+	ResMessage                    string                                `json:"resMessage"`
+	AssetIssueID                  string                                `json:"assetIssueID"`
+	WithdrawAmount                Sun                                   `json:"withdraw_amount"`
+	UnfreezeAmount                Sun                                   `json:"unfreeze_amount"`
+	InternalTransactions          []InternalTransaction                 `json:"internal_transactions"`
+	ExchangeReceivedAmount        int64                                 `json:"exchange_received_amount"`
+	ExchangeInjectAnotherAmount   int64                                 `json:"exchange_inject_another_amount"`
+	ExchangeWithdrawAnotherAmount int64                                 `json:"exchange_withdraw_another_amount"`
+	ExchangeId                    int64                                 `json:"exchange_id"`
+	ShieldedTransactionFee        Sun                                   `json:"shielded_transaction_fee"`
+	OrderId                       string                                `json:"orderId"`
+	OrderDetails                  []MarketOrderDetail                   `json:"orderDetails"`
+	PackingFee                    Sun                                   `json:"packingFee"`
+	WithdrawExpireAmount          Sun                                   `json:"withdraw_expire_amount"`
+	CancelUnfreezeV2Amount        []struct {
+		Key   string `json:"key"`
+		Value Sun    `json:"value"`
+	} `json:"cancel_unfreezeV2_amount"`
+	// Consistency is the confirmation level the info was read at.
+	Consistency Consistency `json:"-"`
+}
+
+// UnmarshalJSON decodes the response and turns the hex-encoded resMessage
+// into text.
+func (r *GetTransactionInfoByIDResponse) UnmarshalJSON(b []byte) error {
+
+	type response GetTransactionInfoByIDResponse
+
+	err := json.Unmarshal(b, (*response)(r))
+	if err != nil {
+		return err
+	}
+
+	r.ResMessage = decodeMessage(r.ResMessage)
+
+	return nil
+}
+
+// MarshalJSON encodes the response as the node does, with a hex-encoded
+// resMessage, so that it decodes back to the same value.
+func (r GetTransactionInfoByIDResponse) MarshalJSON() ([]byte, error) {
+
+	type response GetTransactionInfoByIDResponse
+
+	r.ResMessage = hex.EncodeToString([]byte(r.ResMessage))
+
+	return json.Marshal(response(r))
+}
+
+// Failed reports whether the transaction was included in a block but
+// failed, e.g. because the contract reverted or ran out of energy.
+// ResMessage and Receipt.Result tell why.
+func (r *GetTransactionInfoByIDResponse) Failed() bool {
+	return r.Result == TransactionInfoFailed ||
+		(r.Receipt.Result != "" && r.Receipt.Result != ContractResultSuccess)
+}
+
+// TransactionInfoResult is the result of a transaction, the
+// TransactionInfoCode of the core types.
+type TransactionInfoResult int
+
+const (
+	TransactionInfoSuccess TransactionInfoResult = iota
+	TransactionInfoFailed
+)
+
+func (r TransactionInfoResult) String() string {
+	if r == TransactionInfoFailed {
+		return "FAILED"
+	}
+
+	return "SUCCESS"
+}
+
+func (r TransactionInfoResult) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText accepts FAILED and SUCESS, as spelled by the node, or
+// SUCCESS.
+func (r *TransactionInfoResult) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "", "SUCESS", "SUCCESS":
+		*r = TransactionInfoSuccess
+	case "FAILED":
+		*r = TransactionInfoFailed
+	default:
+		return fmt.Errorf("unknown transaction result %q", text)
+	}
+
+	return nil
+}
+
+// ContractResult is the result of the contract execution in a receipt.
+type ContractResult string
+
+const (
+	ContractResultDefault        ContractResult = "DEFAULT"
+	ContractResultSuccess        ContractResult = "SUCCESS"
+	ContractResultRevert         ContractResult = "REVERT"
+	ContractResultOutOfEnergy    ContractResult = "OUT_OF_ENERGY"
+	ContractResultOutOfTime      ContractResult = "OUT_OF_TIME"
+	ContractResultTransferFailed ContractResult = "TRANSFER_FAILED"
+)
+
+type MarketOrderDetail struct {
+	MakerOrderId     string `json:"makerOrderId"`
+	TakerOrderId     string `json:"takerOrderId"`
+	FillSellQuantity int64  `json:"fillSellQuantity"`
+	FillBuyQuantity  int64  `json:"fillBuyQuantity"`
 }
 
 type GetTransactionInfoByIDResponseReceipt struct {
-	EnergyUsage        int    `json:"energy_usage"`
-	EnergyFee          int    `json:"energy_fee"`
-	OriginEnergyUsage  int    `json:"origin_energy_usage"`
-	EnergyUsageTotal   int    `json:"energy_usage_total"`
-	NetUsage           int    `json:"net_usage"`
-	NetFee             int    `json:"net_fee"`
-	Result             string `json:"result"`
-	EnergyPenaltyTotal int    `json:"energy_penalty_total"`
+	EnergyUsage        int            `json:"energy_usage"`
+	EnergyFee          Sun            `json:"energy_fee"`
+	OriginEnergyUsage  int            `json:"origin_energy_usage"`
+	EnergyUsageTotal   int            `json:"energy_usage_total"`
+	NetUsage           int            `json:"net_usage"`
+	NetFee             Sun            `json:"net_fee"`
+	Result             ContractResult `json:"result"`
+	EnergyPenaltyTotal int            `json:"energy_penalty_total"`
+}
+
+// Log is an event emitted by a contract. Topics and Data are hex encoded;
+// the first topic identifies the event unless it is anonymous.
+type Log struct {
+	Address Address  `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// InternalTransaction is a call or transfer made by a contract while
+// executing a transaction. Note names the kind, e.g. "call", hex encoded.
+type InternalTransaction struct {
+	Hash              string  `json:"hash"`
+	CallerAddress     Address `json:"caller_address"`
+	TransferToAddress Address `json:"transferTo_address"`
+	CallValueInfo     []struct {
+		CallValue Sun    `json:"callValue"`
+		TokenId   string `json:"tokenId"`
+	} `json:"callValueInfo"`
+	Note     string `json:"note"`
+	Rejected bool   `json:"rejected"`
 }
@@ -0,0 +1,36 @@
+package trongrid
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTransactionInfoCancelUnfreezeV2Amount(t *testing.T) {
+
+	var info GetTransactionInfoByIDResponse
+	err := json.Unmarshal([]byte(`{"id":"00","cancel_unfreezeV2_amount":[{"key":"ENERGY","value":5}]}`), &info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(info.CancelUnfreezeV2Amount) != 1 || info.CancelUnfreezeV2Amount[0].Key != "ENERGY" || info.CancelUnfreezeV2Amount[0].Value != 5 {
+		t.Fatalf("CancelUnfreezeV2Amount = %+v, want ENERGY 5", info.CancelUnfreezeV2Amount)
+	}
+
+	// The response is cached as JSON and must decode back the same.
+	b, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded GetTransactionInfoByIDResponse
+	err = json.Unmarshal(b, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(decoded, info) {
+		t.Errorf("round trip = %+v, want %+v", decoded, info)
+	}
+}
@@ -0,0 +1,502 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/api"
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// apiKeyMetadata is the gRPC metadata key of the TronGrid API key.
+const apiKeyMetadata = "tron-pro-api-key"
+
+const (
+	walletService         = "protocol.Wallet"
+	walletSolidityService = "protocol.WalletSolidity"
+)
+
+// grpcReader is the part of the API served by both the Wallet and the
+// WalletSolidity services.
+type grpcReader interface {
+	GetNowBlock2(ctx context.Context, in *api.EmptyMessage, opts ...grpc.CallOption) (*api.BlockExtention, error)
+	GetBlockByNum2(ctx context.Context, in *api.NumberMessage, opts ...grpc.CallOption) (*api.BlockExtention, error)
+	GetBlock(ctx context.Context, in *api.BlockReq, opts ...grpc.CallOption) (*api.BlockExtention, error)
+	GetAccount(ctx context.Context, in *core.Account, opts ...grpc.CallOption) (*core.Account, error)
+	GetTransactionInfoById(ctx context.Context, in *api.BytesMessage, opts ...grpc.CallOption) (*core.TransactionInfo, error)
+	TriggerConstantContract(ctx context.Context, in *core.TriggerSmartContract, opts ...grpc.CallOption) (*api.TransactionExtention, error)
+}
+
+// grpcTarget is the service reads are sent to and the confirmation level
+// it serves.
+type grpcTarget struct {
+	reader  grpcReader
+	service string
+	level   Consistency
+}
+
+type grpcClient struct {
+	options clientOptions
+	wallet  api.WalletClient
+	reads   grpcTarget
+}
+
+// NewGRPC returns a Client backed by the gRPC API of a full node, e.g.
+// grpc.trongrid.io:50051. WithNetwork, WithAPIKey, WithRateLimiter and
+// WithConsistency apply; options specific to HTTP are ignored.
+// GetAccountTransactions and GetContractTransaction use the TronGrid /v1
+// API, which has no gRPC equivalent, and return ErrNotSupported.
+func NewGRPC(conn grpc.ClientConnInterface, opts ...ClientOption) Client {
+
+	options := clientOptions{network: NetworkMainnet}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := &grpcClient{
+		options: options,
+		wallet:  api.NewWalletClient(conn),
+	}
+
+	c.reads = grpcTarget{reader: c.wallet, service: walletService, level: ConsistencyLatest}
+	if options.consistency == ConsistencyPBFT || options.consistency == ConsistencySolidified {
+		c.reads = grpcTarget{reader: api.NewWalletSolidityClient(conn), service: walletSolidityService, level: options.consistency}
+	}
+
+	return c
+}
+
+// prepare waits for the rate limiter and attaches the API key.
+func (c *grpcClient) prepare(ctx context.Context) (context.Context, error) {
+
+	if c.options.rateLimiter != nil {
+		err := c.options.rateLimiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.options.apiKey != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, apiKeyMetadata, c.options.apiKey)
+	}
+
+	return ctx, nil
+}
+
+func (c *grpcClient) GetNowBlock(ctx context.Context) (*Block, error) {
+	return c.getNowBlock(ctx, c.reads)
+}
+
+func (c *grpcClient) getNowBlock(ctx context.Context, t grpcTarget) (*Block, error) {
+
+	ctx, err := c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := t.reader.GetNowBlock2(ctx, &api.EmptyMessage{})
+	if err != nil {
+		return nil, grpcError(t.service, "GetNowBlock2", err)
+	}
+
+	if block.GetBlockHeader().GetRawData() == nil {
+		return nil, fmt.Errorf("%w: block %x has no header", ErrNoDataInResponse, block.GetBlockid())
+	}
+
+	converted := blockFromProto(block)
+	converted.Consistency = t.level
+
+	return converted, nil
+}
+
+func (c *grpcClient) GetAccountBalance(ctx context.Context, address Address, blockNumber uint64, blockHash string) (*AccountBalance, error) {
+
+	hash, err := hex.DecodeString(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash %q: %w", blockHash, err)
+	}
+
+	ctx, err = c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := c.wallet.GetAccountBalance(ctx, &core.AccountBalanceRequest{
+		AccountIdentifier: &core.AccountIdentifier{Address: address.Bytes()},
+		BlockIdentifier:   &core.BlockBalanceTrace_BlockIdentifier{Hash: hash, Number: int64(blockNumber)},
+	})
+	if err != nil {
+		return nil, grpcError(walletService, "GetAccountBalance", err)
+	}
+
+	return &AccountBalance{
+		Balance: Sun(balance.GetBalance()),
+		BlockIdentifier: BlockIdentifier{
+			Hash:   hex.EncodeToString(balance.GetBlockIdentifier().GetHash()),
+			Number: uint64(balance.GetBlockIdentifier().GetNumber()),
+		},
+	}, nil
+}
+
+func (c *grpcClient) GetBlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	return c.getBlockByNumber(ctx, c.reads, number)
+}
+
+func (c *grpcClient) getBlockByNumber(ctx context.Context, t grpcTarget, number uint64) (*Block, error) {
+
+	ctx, err := c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := t.reader.GetBlockByNum2(ctx, &api.NumberMessage{Num: int64(number)})
+	if err != nil {
+		return nil, grpcError(t.service, "GetBlockByNum2", err)
+	}
+
+	if len(block.GetBlockid()) == 0 {
+		apiErr := newAPIError(grpcEndpoint(t.service, "GetBlockByNum2"), http.StatusOK, "", fmt.Sprintf("block %d not found", number))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
+	}
+
+	converted := blockFromProto(block)
+	converted.Consistency = t.level
+
+	return converted, nil
+}
+
+func (c *grpcClient) GetBlock(ctx context.Context, idOrNum string, detail bool) (*Block, error) {
+	return c.getBlock(ctx, c.reads, idOrNum, detail)
+}
+
+func (c *grpcClient) getBlock(ctx context.Context, t grpcTarget, idOrNum string, detail bool) (*Block, error) {
+
+	ctx, err := c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := t.reader.GetBlock(ctx, &api.BlockReq{IdOrNum: idOrNum, Detail: detail})
+	if err != nil {
+		return nil, grpcError(t.service, "GetBlock", err)
+	}
+
+	if len(block.GetBlockid()) == 0 {
+		apiErr := newAPIError(grpcEndpoint(t.service, "GetBlock"), http.StatusOK, "", fmt.Sprintf("block %s not found", idOrNum))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
+	}
+
+	converted := blockFromProto(block)
+	converted.Consistency = t.level
+
+	return converted, nil
+}
+
+func (c *grpcClient) GetBlocksByRange(ctx context.Context, start, end uint64) ([]*Block, error) {
+
+	if c.reads.service != walletService {
+		return nil, fmt.Errorf("GetBlocksByRange over %s: %w", c.reads.service, ErrNotSupported)
+	}
+
+	if end < start {
+		return nil, fmt.Errorf("invalid block range [%d, %d)", start, end)
+	}
+
+	blocks := make([]*Block, 0, min(end-start, maxBlocksByRange))
+
+	for from := start; from < end; from += min(end-from, maxBlocksByRange) {
+		callCtx, err := c.prepare(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		list, err := c.wallet.GetBlockByLimitNext2(callCtx, &api.BlockLimit{
+			StartNum: int64(from),
+			EndNum:   int64(from + min(end-from, maxBlocksByRange)),
+		})
+		if err != nil {
+			return nil, grpcError(walletService, "GetBlockByLimitNext2", err)
+		}
+
+		blocks = append(blocks, c.blocksFromProto(list)...)
+	}
+
+	sortBlocks(blocks)
+
+	return blocks, nil
+}
+
+func (c *grpcClient) GetLatestBlocks(ctx context.Context, n int) ([]*Block, error) {
+
+	if c.reads.service != walletService {
+		return nil, fmt.Errorf("GetLatestBlocks over %s: %w", c.reads.service, ErrNotSupported)
+	}
+
+	if n < 0 {
+		return nil, fmt.Errorf("invalid block count %d", n)
+	}
+
+	if n == 0 {
+		return []*Block{}, nil
+	}
+
+	callCtx, err := c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.wallet.GetBlockByLatestNum2(callCtx, &api.NumberMessage{Num: int64(min(n, maxBlocksByLatest))})
+	if err != nil {
+		return nil, grpcError(walletService, "GetBlockByLatestNum2", err)
+	}
+
+	blocks := c.blocksFromProto(list)
+	sortBlocks(blocks)
+
+	start, end, ok := olderRange(blocks, n)
+	if !ok {
+		return blocks, nil
+	}
+
+	older, err := c.GetBlocksByRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(older, blocks...), nil
+}
+
+func (c *grpcClient) blocksFromProto(list *api.BlockListExtention) []*Block {
+
+	blocks := make([]*Block, 0, len(list.GetBlock()))
+
+	for _, block := range list.GetBlock() {
+		converted := blockFromProto(block)
+		converted.Consistency = c.reads.level
+
+		blocks = append(blocks, converted)
+	}
+
+	return blocks
+}
+
+func (c *grpcClient) GetAccount(ctx context.Context, address Address) (*Account, error) {
+	return c.getAccount(ctx, c.reads, address)
+}
+
+func (c *grpcClient) getAccount(ctx context.Context, t grpcTarget, address Address) (*Account, error) {
+
+	ctx, err := c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := t.reader.GetAccount(ctx, &core.Account{Address: address.Bytes()})
+	if err != nil {
+		return nil, grpcError(t.service, "GetAccount", err)
+	}
+
+	converted := accountFromProto(account)
+	converted.Consistency = t.level
+
+	return converted, nil
+}
+
+func (c *grpcClient) GetAccountTransactions(ctx context.Context, address Address, opts ...GetAccountTransactionsOption) (*GetAccountTransactionsCursor, error) {
+	return nil, fmt.Errorf("GetAccountTransactions over gRPC: %w", ErrNotSupported)
+}
+
+func (c *grpcClient) GetContractTransaction(ctx context.Context, address Address, contractType string, opts ...GetContractTransactionOption) (*GetContractTransactionCursor, error) {
+	return nil, fmt.Errorf("GetContractTransaction over gRPC: %w", ErrNotSupported)
+}
+
+func (c *grpcClient) BroadcastHex(ctx context.Context, req *BroadcastHexRequest) (*BroadcastHexResponse, error) {
+
+	b, err := hex.DecodeString(req.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction hex: %w", err)
+	}
+
+	var tx core.Transaction
+	err = proto.Unmarshal(b, &tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	ctx, err = c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.wallet.BroadcastTransaction(ctx, &tx)
+	if err != nil {
+		return nil, grpcError(walletService, "BroadcastTransaction", err)
+	}
+
+	response := &BroadcastHexResponse{
+		Result:      result.GetResult(),
+		Code:        result.GetCode().String(),
+		Txid:        hex.EncodeToString(rawDataID(b)),
+		Message:     string(result.GetMessage()),
+		Transaction: req.Transaction,
+	}
+
+	if !response.Result {
+		return nil, newAPIError(grpcEndpoint(walletService, "BroadcastTransaction"), http.StatusOK, response.Code, response.Message)
+	}
+
+	return response, nil
+}
+
+func (c *grpcClient) TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+	return c.triggerConstantContract(ctx, c.reads, req)
+}
+
+func (c *grpcClient) triggerConstantContract(ctx context.Context, t grpcTarget, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+
+	data, err := hex.DecodeString(req.Parameter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter: %w", err)
+	}
+
+	if req.FunctionSelector != "" {
+		h := sha3.NewLegacyKeccak256()
+		h.Write([]byte(req.FunctionSelector))
+		data = append(h.Sum(nil)[:4], data...)
+	}
+
+	ctx, err = c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	trigger := &core.TriggerSmartContract{
+		ContractAddress: req.ContractAddress.Bytes(),
+		Data:            data,
+	}
+	if !req.OwnerAddress.IsZero() {
+		trigger.OwnerAddress = req.OwnerAddress.Bytes()
+	}
+
+	tx, err := t.reader.TriggerConstantContract(ctx, trigger)
+	if err != nil {
+		return nil, grpcError(t.service, "TriggerConstantContract", err)
+	}
+
+	response := triggerConstantContractFromProto(tx)
+
+	result := response.Result
+	if result.Code != "" && result.Code != CodeSuccess {
+		return nil, newAPIError(grpcEndpoint(t.service, "TriggerConstantContract"), http.StatusOK, result.Code, result.Message)
+	}
+
+	response.Consistency = t.level
+
+	return response, nil
+}
+
+func (c *grpcClient) GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error) {
+	return c.getTransactionInfoByID(ctx, c.reads, txID)
+}
+
+func (c *grpcClient) getTransactionInfoByID(ctx context.Context, t grpcTarget, txID string) (*GetTransactionInfoByIDResponse, error) {
+
+	id, err := hex.DecodeString(txID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction id %q: %w", txID, err)
+	}
+
+	ctx, err = c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := t.reader.GetTransactionInfoById(ctx, &api.BytesMessage{Value: id})
+	if err != nil {
+		return nil, grpcError(t.service, "GetTransactionInfoById", err)
+	}
+
+	if len(info.GetId()) == 0 {
+		apiErr := newAPIError(grpcEndpoint(t.service, "GetTransactionInfoById"), http.StatusOK, "", fmt.Sprintf("transaction %s not found", txID))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
+	}
+
+	converted := transactionInfoFromProto(info)
+	converted.Consistency = t.level
+
+	return converted, nil
+}
+
+func (c *grpcClient) GetContract(ctx context.Context, address Address) (*SmartContract, error) {
+
+	ctx, err := c.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contract, err := c.wallet.GetContract(ctx, &api.BytesMessage{Value: address.Bytes()})
+	if err != nil {
+		return nil, grpcError(walletService, "GetContract", err)
+	}
+
+	if len(contract.GetContractAddress()) == 0 {
+		apiErr := newAPIError(grpcEndpoint(walletService, "GetContract"), http.StatusOK, "", fmt.Sprintf("contract %s not found", address))
+		apiErr.Err = ErrNotFound
+		return nil, apiErr
+	}
+
+	return smartContractFromProto(contract)
+}
+
+func grpcEndpoint(service, method string) string {
+	return "grpc:/" + service + "/" + method
+}
+
+// grpcError turns a gRPC status into an *APIError, with the HTTP status
+// equivalent to its code so it matches the same sentinel errors.
+func grpcError(service, method string, err error) error {
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	statusCode := http.StatusInternalServerError
+	switch st.Code() {
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	case codes.ResourceExhausted:
+		statusCode = http.StatusTooManyRequests
+	case codes.NotFound:
+		statusCode = http.StatusNotFound
+	case codes.PermissionDenied, codes.Unauthenticated:
+		statusCode = http.StatusForbidden
+	case codes.InvalidArgument:
+		statusCode = http.StatusBadRequest
+	case codes.Unavailable:
+		statusCode = http.StatusServiceUnavailable
+	case codes.Unimplemented:
+		statusCode = http.StatusNotImplemented
+	}
+
+	return newAPIError(grpcEndpoint(service, method), statusCode, "", st.Message())
+}
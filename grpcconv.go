@@ -0,0 +1,429 @@
+package trongrid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/api"
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Conversions from the protobuf messages of the gRPC API to the types of
+// the HTTP API. Bytes are hex encoded and enums named as the HTTP API
+// does, which omits zero values such as the Owner permission type.
+
+func addressFromProto(b []byte) Address {
+	address, _ := AddressFromBytes(b)
+	return address
+}
+
+func blockFromProto(block *api.BlockExtention) *Block {
+
+	converted := &Block{BlockID: hex.EncodeToString(block.GetBlockid())}
+
+	header := block.GetBlockHeader()
+	if header.GetRawData() == nil {
+		return converted
+	}
+
+	raw := header.GetRawData()
+
+	converted.BlockHeader = &BlockHeader{
+		RawData: &BlocHeaderRawData{
+			Number:         int(raw.GetNumber()),
+			TxTrieRoot:     hex.EncodeToString(raw.GetTxTrieRoot()),
+			WitnessAddress: addressFromProto(raw.GetWitnessAddress()),
+			ParentHash:     hex.EncodeToString(raw.GetParentHash()),
+			Version:        int(raw.GetVersion()),
+			Timestamp:      raw.GetTimestamp(),
+		},
+		WitnessSignature: hex.EncodeToString(header.GetWitnessSignature()),
+	}
+
+	for _, tx := range block.GetTransactions() {
+		converted.Transactions = append(converted.Transactions, blockTransactionFromProto(tx))
+	}
+
+	return converted
+}
+
+func blockTransactionFromProto(tx *api.TransactionExtention) *BlockTransaction {
+
+	converted := &BlockTransaction{TxID: hex.EncodeToString(tx.GetTxid())}
+
+	for _, ret := range tx.GetTransaction().GetRet() {
+		result := TransactionRet{Fee: Sun(ret.GetFee())}
+		if ret.GetContractRet() != core.Transaction_Result_DEFAULT {
+			result.ContractRet = ret.GetContractRet().String()
+		}
+
+		converted.Ret = append(converted.Ret, result)
+	}
+
+	for _, signature := range tx.GetTransaction().GetSignature() {
+		converted.Signature = append(converted.Signature, hex.EncodeToString(signature))
+	}
+
+	raw := tx.GetTransaction().GetRawData()
+	if raw == nil {
+		return converted
+	}
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(raw)
+	if err == nil {
+		converted.RawDataHex = hex.EncodeToString(b)
+	}
+
+	converted.RawData = TransactionRawData{
+		RefBlockBytes: hex.EncodeToString(raw.GetRefBlockBytes()),
+		RefBlockHash:  hex.EncodeToString(raw.GetRefBlockHash()),
+		Expiration:    raw.GetExpiration(),
+		Timestamp:     raw.GetTimestamp(),
+		FeeLimit:      Sun(raw.GetFeeLimit()),
+		Data:          hex.EncodeToString(raw.GetData()),
+	}
+
+	for _, contract := range raw.GetContract() {
+		var c TransactionContract
+		c.Type = contract.GetType().String()
+		c.PermissionId = int(contract.GetPermissionId())
+		c.Parameter.TypeUrl = contract.GetParameter().GetTypeUrl()
+		c.Parameter.Value = contractValueFromProto(contract.GetParameter())
+
+		converted.RawData.Contract = append(converted.RawData.Contract, c)
+	}
+
+	return converted
+}
+
+// contractValueFromProto fills a ContractValue from the fields of a
+// contract parameter, matched by their names. Bytes fields named
+// *_address are addresses, other bytes are hex encoded and enums named.
+// Fields of unknown contract types and nested messages are dropped.
+func contractValueFromProto(parameter *anypb.Any) ContractValue {
+
+	var converted ContractValue
+
+	contract, err := parameter.UnmarshalNew()
+	if err != nil {
+		return converted
+	}
+
+	fields := make(map[string]interface{})
+	contract.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+
+		name := string(fd.Name())
+
+		switch {
+		case fd.IsList() || fd.IsMap() || fd.Message() != nil:
+		case fd.Kind() == protoreflect.BytesKind && strings.HasSuffix(name, "_address"):
+			fields[name] = addressFromProto(v.Bytes())
+		case fd.Kind() == protoreflect.BytesKind:
+			fields[name] = hex.EncodeToString(v.Bytes())
+		case fd.Kind() == protoreflect.EnumKind:
+			if value := fd.Enum().Values().ByNumber(v.Enum()); value != nil {
+				fields[name] = string(value.Name())
+			}
+		default:
+			fields[name] = v.Interface()
+		}
+
+		return true
+	})
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return converted
+	}
+
+	// Fields of another type than in ContractValue are skipped.
+	_ = json.Unmarshal(b, &converted)
+
+	return converted
+}
+
+func accountFromProto(account *core.Account) *Account {
+
+	converted := &Account{
+		Address:               addressFromProto(account.GetAddress()),
+		Balance:               Sun(account.GetBalance()),
+		CreateTime:            account.GetCreateTime(),
+		LatestOprationTime:    account.GetLatestOprationTime(),
+		LatestConsumeFreeTime: account.GetLatestConsumeFreeTime(),
+		NetWindowSize:         int(account.GetNetWindowSize()),
+		NetWindowOptimized:    account.GetNetWindowOptimized(),
+		AssetOptimized:        account.GetAssetOptimized(),
+	}
+
+	resource := account.GetAccountResource()
+	converted.AccountResource.LatestConsumeTimeForEnergy = resource.GetLatestConsumeTimeForEnergy()
+	converted.AccountResource.EnergyWindowSize = int(resource.GetEnergyWindowSize())
+	converted.AccountResource.EnergyWindowOptimized = resource.GetEnergyWindowOptimized()
+
+	if account.GetOwnerPermission() != nil {
+		converted.OwnerPermission = permissionFromProto(account.GetOwnerPermission())
+	}
+
+	for _, permission := range account.GetActivePermission() {
+		converted.ActivePermission = append(converted.ActivePermission, permissionFromProto(permission))
+	}
+
+	for _, frozen := range account.GetFrozenV2() {
+		resourceType := ""
+		if frozen.GetType() != core.ResourceCode_BANDWIDTH {
+			resourceType = frozen.GetType().String()
+		}
+
+		converted.FrozenV2 = append(converted.FrozenV2, struct {
+			Type string `json:"type,omitempty"`
+		}{Type: resourceType})
+	}
+
+	for key, value := range account.GetAssetV2() {
+		converted.AssetV2 = append(converted.AssetV2, struct {
+			Key   string `json:"key"`
+			Value int64  `json:"value"`
+		}{Key: key, Value: value})
+	}
+
+	for key, value := range account.GetFreeAssetNetUsageV2() {
+		converted.FreeAssetNetUsageV2 = append(converted.FreeAssetNetUsageV2, struct {
+			Key   string `json:"key"`
+			Value int    `json:"value"`
+		}{Key: key, Value: int(value)})
+	}
+
+	return converted
+}
+
+func permissionFromProto(permission *core.Permission) Permission {
+
+	converted := Permission{
+		Id:             int(permission.GetId()),
+		PermissionName: permission.GetPermissionName(),
+		Threshold:      int(permission.GetThreshold()),
+		Operations:     hex.EncodeToString(permission.GetOperations()),
+	}
+
+	if permission.GetType() != core.Permission_Owner {
+		converted.Type = permission.GetType().String()
+	}
+
+	for _, key := range permission.GetKeys() {
+		converted.Keys = append(converted.Keys, PermissionKey{
+			Address: addressFromProto(key.GetAddress()),
+			Weight:  int(key.GetWeight()),
+		})
+	}
+
+	return converted
+}
+
+func triggerConstantContractFromProto(tx *api.TransactionExtention) *TriggerConstantContractResponse {
+
+	converted := &TriggerConstantContractResponse{
+		EnergyUsed:    int(tx.GetEnergyUsed()),
+		EnergyPenalty: int(tx.GetEnergyPenalty()),
+	}
+
+	converted.Result.Result = tx.GetResult().GetResult()
+	converted.Result.Code = tx.GetResult().GetCode().String()
+	converted.Result.Message = string(tx.GetResult().GetMessage())
+
+	for _, result := range tx.GetConstantResult() {
+		converted.ConstantResult = append(converted.ConstantResult, hex.EncodeToString(result))
+	}
+
+	converted.Transaction.TxID = hex.EncodeToString(tx.GetTxid())
+
+	for _, ret := range tx.GetTransaction().GetRet() {
+		converted.Transaction.Ret = append(converted.Transaction.Ret, struct {
+			Ret         string `json:"ret"`
+			ContractRet string `json:"contractRet"`
+		}{Ret: ret.GetRet().String(), ContractRet: ret.GetContractRet().String()})
+	}
+
+	if raw := tx.GetTransaction().GetRawData(); raw != nil {
+		b, err := proto.MarshalOptions{Deterministic: true}.Marshal(raw)
+		if err == nil {
+			converted.Transaction.RawDataHex = hex.EncodeToString(b)
+		}
+
+		converted.Transaction.RawData.RefBlockBytes = hex.EncodeToString(raw.GetRefBlockBytes())
+		converted.Transaction.RawData.RefBlockHash = hex.EncodeToString(raw.GetRefBlockHash())
+		converted.Transaction.RawData.Expiration = raw.GetExpiration()
+		converted.Transaction.RawData.Timestamp = raw.GetTimestamp()
+	}
+
+	return converted
+}
+
+func transactionInfoFromProto(info *core.TransactionInfo) *GetTransactionInfoByIDResponse {
+
+	converted := &GetTransactionInfoByIDResponse{
+		Id:                            hex.EncodeToString(info.GetId()),
+		Fee:                           Sun(info.GetFee()),
+		BlockNumber:                   int(info.GetBlockNumber()),
+		BlockTimeStamp:                info.GetBlockTimeStamp(),
+		ContractAddress:               addressFromProto(info.GetContractAddress()),
+		ResMessage:                    string(info.GetResMessage()),
+		AssetIssueID:                  info.GetAssetIssueID(),
+		WithdrawAmount:                Sun(info.GetWithdrawAmount()),
+		UnfreezeAmount:                Sun(info.GetUnfreezeAmount()),
+		ExchangeReceivedAmount:        info.GetExchangeReceivedAmount(),
+		ExchangeInjectAnotherAmount:   info.GetExchangeInjectAnotherAmount(),
+		ExchangeWithdrawAnotherAmount: info.GetExchangeWithdrawAnotherAmount(),
+		ExchangeId:                    info.GetExchangeId(),
+		ShieldedTransactionFee:        Sun(info.GetShieldedTransactionFee()),
+		OrderId:                       hex.EncodeToString(info.GetOrderId()),
+		PackingFee:                    Sun(info.GetPackingFee()),
+		WithdrawExpireAmount:          Sun(info.GetWithdrawExpireAmount()),
+	}
+
+	if info.GetResult() == core.TransactionInfo_FAILED {
+		converted.Result = TransactionInfoFailed
+	}
+
+	for _, result := range info.GetContractResult() {
+		converted.ContractResult = append(converted.ContractResult, hex.EncodeToString(result))
+	}
+
+	receipt := info.GetReceipt()
+	converted.Receipt = GetTransactionInfoByIDResponseReceipt{
+		EnergyUsage:        int(receipt.GetEnergyUsage()),
+		EnergyFee:          Sun(receipt.GetEnergyFee()),
+		OriginEnergyUsage:  int(receipt.GetOriginEnergyUsage()),
+		EnergyUsageTotal:   int(receipt.GetEnergyUsageTotal()),
+		NetUsage:           int(receipt.GetNetUsage()),
+		NetFee:             Sun(receipt.GetNetFee()),
+		EnergyPenaltyTotal: int(receipt.GetEnergyPenaltyTotal()),
+	}
+
+	if receipt.GetResult() != core.Transaction_Result_DEFAULT {
+		converted.Receipt.Result = ContractResult(receipt.GetResult().String())
+	}
+
+	for _, log := range info.GetLog() {
+		converted.Log = append(converted.Log, logFromProto(log))
+	}
+
+	for _, internal := range info.GetInternalTransactions() {
+		converted.InternalTransactions = append(converted.InternalTransactions, internalTransactionFromProto(internal))
+	}
+
+	for _, order := range info.GetOrderDetails() {
+		converted.OrderDetails = append(converted.OrderDetails, MarketOrderDetail{
+			MakerOrderId:     hex.EncodeToString(order.GetMakerOrderId()),
+			TakerOrderId:     hex.EncodeToString(order.GetTakerOrderId()),
+			FillSellQuantity: order.GetFillSellQuantity(),
+			FillBuyQuantity:  order.GetFillBuyQuantity(),
+		})
+	}
+
+	for resource, amount := range info.GetCancelUnfreezeV2Amount() {
+		converted.CancelUnfreezeV2Amount = append(converted.CancelUnfreezeV2Amount, struct {
+			Key   string `json:"key"`
+			Value Sun    `json:"value"`
+		}{Key: resource, Value: Sun(amount)})
+	}
+
+	return converted
+}
+
+func logFromProto(log *core.TransactionInfo_Log) Log {
+
+	converted := Log{
+		Address: addressFromProto(log.GetAddress()),
+		Data:    hex.EncodeToString(log.GetData()),
+	}
+
+	for _, topic := range log.GetTopics() {
+		converted.Topics = append(converted.Topics, hex.EncodeToString(topic))
+	}
+
+	return converted
+}
+
+func internalTransactionFromProto(internal *core.InternalTransaction) InternalTransaction {
+
+	converted := InternalTransaction{
+		Hash:              hex.EncodeToString(internal.GetHash()),
+		CallerAddress:     addressFromProto(internal.GetCallerAddress()),
+		TransferToAddress: addressFromProto(internal.GetTransferToAddress()),
+		Note:              hex.EncodeToString(internal.GetNote()),
+		Rejected:          internal.GetRejected(),
+	}
+
+	for _, info := range internal.GetCallValueInfo() {
+		converted.CallValueInfo = append(converted.CallValueInfo, struct {
+			CallValue Sun    `json:"callValue"`
+			TokenId   string `json:"tokenId"`
+		}{CallValue: Sun(info.GetCallValue()), TokenId: info.GetTokenId()})
+	}
+
+	return converted
+}
+
+func smartContractFromProto(contract *core.SmartContract) (*SmartContract, error) {
+
+	converted := &SmartContract{
+		OriginAddress:              addressFromProto(contract.GetOriginAddress()),
+		ContractAddress:            addressFromProto(contract.GetContractAddress()),
+		Bytecode:                   hex.EncodeToString(contract.GetBytecode()),
+		CallValue:                  Sun(contract.GetCallValue()),
+		ConsumeUserResourcePercent: int(contract.GetConsumeUserResourcePercent()),
+		Name:                       contract.GetName(),
+		OriginEnergyLimit:          contract.GetOriginEnergyLimit(),
+		CodeHash:                   hex.EncodeToString(contract.GetCodeHash()),
+		Version:                    int(contract.GetVersion()),
+	}
+
+	if contract.GetAbi() != nil {
+		abi, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(contract.GetAbi())
+		if err != nil {
+			return nil, err
+		}
+
+		converted.ABI = abi
+	}
+
+	return converted, nil
+}
+
+// rawDataID returns the id of a serialized transaction: the SHA-256 hash
+// of its raw data bytes as they were serialized.
+func rawDataID(tx []byte) []byte {
+
+	for len(tx) > 0 {
+		number, typ, n := protowire.ConsumeTag(tx)
+		if n < 0 {
+			return nil
+		}
+		tx = tx[n:]
+
+		if number == 1 && typ == protowire.BytesType {
+			raw, n := protowire.ConsumeBytes(tx)
+			if n < 0 {
+				return nil
+			}
+
+			sum := sha256.Sum256(raw)
+			return sum[:]
+		}
+
+		n = protowire.ConsumeFieldValue(number, typ, tx)
+		if n < 0 {
+			return nil
+		}
+		tx = tx[n:]
+	}
+
+	return nil
+}
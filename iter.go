@@ -0,0 +1,63 @@
+package trongrid
+
+import "iter"
+
+// Timestamped is implemented by items that carry the timestamp of the
+// block they were included in.
+type Timestamped interface {
+	GetBlockTimestamp() int64
+}
+
+// Collect returns up to n items of seq, or all of them if n <= 0. It stops
+// at the first error and returns the items collected so far with it.
+func Collect[T any](seq iter.Seq2[T, error], n int) ([]T, error) {
+
+	var items []T
+
+	for item, err := range seq {
+		if err != nil {
+			return items, err
+		}
+
+		items = append(items, item)
+
+		if n > 0 && len(items) >= n {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// Until yields the items of seq until stop returns true for one of them.
+// That item is not yielded. Errors are passed through.
+func Until[T any](seq iter.Seq2[T, error], stop func(T) bool) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range seq {
+			if err == nil && stop(item) {
+				return
+			}
+
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
+
+// UntilBefore yields the items of seq until one has a block timestamp
+// before timestamp, in milliseconds. Use it with the default newest-first
+// order.
+func UntilBefore[T Timestamped](seq iter.Seq2[T, error], timestamp int64) iter.Seq2[T, error] {
+	return Until(seq, func(item T) bool {
+		return item.GetBlockTimestamp() < timestamp
+	})
+}
+
+// UntilAfter yields the items of seq until one has a block timestamp after
+// timestamp, in milliseconds. Use it with oldest-first order.
+func UntilAfter[T Timestamped](seq iter.Seq2[T, error], timestamp int64) iter.Seq2[T, error] {
+	return Until(seq, func(item T) bool {
+		return item.GetBlockTimestamp() > timestamp
+	})
+}
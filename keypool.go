@@ -0,0 +1,174 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultKeyBenchDuration is how long a key is left out of rotation after
+// TronGrid rejects it, unless the response carries a longer Retry-After.
+const defaultKeyBenchDuration = 30 * time.Second
+
+// PooledAPIKey is a TronGrid API key managed by a KeyPool.
+type PooledAPIKey struct {
+	Key string
+	// RateLimiter, if set, limits the requests sent with this key on top
+	// of the client-wide rate limiter.
+	RateLimiter RateLimiter
+}
+
+// KeyUsage holds the usage counters of a single pooled key.
+type KeyUsage struct {
+	Key          string
+	Requests     uint64
+	Failures     uint64
+	RateLimited  uint64
+	BenchedUntil time.Time
+}
+
+// KeyPool rotates requests across several TronGrid API keys. Keys that are
+// answered with 403 or 429 are benched for a while and skipped; the call
+// is resent with the next key after the retry policy's backoff, unless it
+// is a broadcast the node may have accepted. Resends with another key
+// count against the policy's MaxAttempts.
+type KeyPool struct {
+	mu            sync.Mutex
+	keys          []*pooledKeyState
+	next          int
+	benchDuration time.Duration
+}
+
+type pooledKeyState struct {
+	PooledAPIKey
+	usage KeyUsage
+}
+
+// NewKeyPool returns a pool rotating across keys in round-robin order.
+func NewKeyPool(keys ...PooledAPIKey) *KeyPool {
+
+	pool := &KeyPool{
+		benchDuration: defaultKeyBenchDuration,
+	}
+
+	for _, key := range keys {
+		pool.keys = append(pool.keys, &pooledKeyState{
+			PooledAPIKey: key,
+			usage:        KeyUsage{Key: key.Key},
+		})
+	}
+
+	return pool
+}
+
+// SetBenchDuration changes how long rejected keys are benched.
+func (p *KeyPool) SetBenchDuration(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.benchDuration = d
+}
+
+// Usage returns a snapshot of the per-key usage counters.
+func (p *KeyPool) Usage() []KeyUsage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	usage := make([]KeyUsage, 0, len(p.keys))
+	for _, k := range p.keys {
+		usage = append(usage, k.usage)
+	}
+
+	return usage
+}
+
+// WithKeyPool sends requests with keys taken from pool instead of the
+// single WithAPIKey key. Endpoints configured with their own APIKey keep
+// using it.
+func WithKeyPool(pool *KeyPool) ClientOption {
+	return func(o *clientOptions) {
+		o.keyPool = pool
+	}
+}
+
+// pick returns the next key that is not benched and not in tried. When
+// every remaining key is benched the one released first is returned.
+func (p *KeyPool) pick(tried map[*pooledKeyState]bool) *pooledKeyState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	var soonest *pooledKeyState
+	for i := 0; i < len(p.keys); i++ {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if tried[k] {
+			continue
+		}
+
+		if !now.Before(k.usage.BenchedUntil) {
+			p.next = (p.next + i + 1) % len(p.keys)
+			k.usage.Requests++
+			return k
+		}
+
+		if soonest == nil || k.usage.BenchedUntil.Before(soonest.usage.BenchedUntil) {
+			soonest = k
+		}
+	}
+
+	if soonest != nil {
+		soonest.usage.Requests++
+	}
+
+	return soonest
+}
+
+// report records the outcome of a request sent with k and benches it when
+// TronGrid rejected the key.
+func (p *KeyPool) report(k *pooledKeyState, err error) {
+	if err == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	k.usage.Failures++
+
+	if !isKeyRejected(err) {
+		return
+	}
+
+	k.usage.RateLimited++
+
+	bench := p.benchDuration
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > bench {
+		bench = apiErr.RetryAfter
+	}
+
+	k.usage.BenchedUntil = time.Now().Add(bench)
+}
+
+func (k *pooledKeyState) wait(ctx context.Context) error {
+	if k.RateLimiter == nil {
+		return nil
+	}
+
+	return k.RateLimiter.Wait(ctx)
+}
+
+// isKeyRejected reports whether TronGrid refused the API key itself.
+func isKeyRejected(err error) bool {
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	return apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusTooManyRequests
+}
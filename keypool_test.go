@@ -0,0 +1,122 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// keyServer answers body to requests, except those sent with the keys in
+// rejected, which get status. It records the key of every request.
+func keyServer(t *testing.T, status int, body string, rejected ...string) (string, func() []string) {
+	t.Helper()
+
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	server, _ := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		key := r.Header.Get("TRON-PRO-API-KEY")
+
+		mu.Lock()
+		keys = append(keys, key)
+		mu.Unlock()
+
+		if slices.Contains(rejected, key) {
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"Error":"key rejected"}`)
+			return
+		}
+
+		fmt.Fprint(w, body)
+	})
+
+	return server.URL, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(keys)
+	}
+}
+
+func TestKeyPoolBenchesRejectedKey(t *testing.T) {
+
+	url, keys := keyServer(t, http.StatusTooManyRequests, nowBlockJSON, "a")
+
+	pool := NewKeyPool(PooledAPIKey{Key: "a"}, PooledAPIKey{Key: "b"})
+	c := New(WithBaseURL(url), WithKeyPool(pool), WithRetryPolicy(testPolicy))
+
+	for range 3 {
+		_, err := c.GetNowBlock(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Key a is tried once, then benched for the following calls.
+	if want := []string{"a", "b", "b", "b"}; !slices.Equal(keys(), want) {
+		t.Errorf("sent keys %v, want %v", keys(), want)
+	}
+
+	usage := pool.Usage()
+	if usage[0].RateLimited != 1 || !usage[0].BenchedUntil.After(time.Now()) {
+		t.Errorf("key a usage = %+v, want it benched", usage[0])
+	}
+
+	if usage[1].Requests != 3 || usage[1].Failures != 0 {
+		t.Errorf("key b usage = %+v, want 3 successful requests", usage[1])
+	}
+}
+
+func TestKeyPoolMaxAttempts(t *testing.T) {
+
+	url, keys := keyServer(t, http.StatusTooManyRequests, nowBlockJSON, "a", "b", "c", "d", "e")
+
+	pool := NewKeyPool(PooledAPIKey{Key: "a"}, PooledAPIKey{Key: "b"}, PooledAPIKey{Key: "c"}, PooledAPIKey{Key: "d"}, PooledAPIKey{Key: "e"})
+	c := New(WithBaseURL(url), WithKeyPool(pool), WithRetryPolicy(testPolicy))
+
+	_, err := c.GetNowBlock(context.Background())
+	if err == nil {
+		t.Fatal("GetNowBlock succeeded with every key rejected")
+	}
+
+	if len(keys()) != testPolicy.MaxAttempts {
+		t.Errorf("sent %d requests with keys %v, want %d", len(keys()), keys(), testPolicy.MaxAttempts)
+	}
+}
+
+func TestKeyPoolBroadcast(t *testing.T) {
+
+	const broadcastJSON = `{"result":true,"txid":"00"}`
+
+	tests := []struct {
+		name   string
+		status int
+		want   []string
+	}{
+		// A rate limited broadcast was not processed and is resent.
+		{name: "rate limited", status: http.StatusTooManyRequests, want: []string{"a", "b"}},
+		// A plain 403 does not prove that, so it is not.
+		{name: "forbidden", status: http.StatusForbidden, want: []string{"a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			url, keys := keyServer(t, tt.status, broadcastJSON, "a")
+
+			pool := NewKeyPool(PooledAPIKey{Key: "a"}, PooledAPIKey{Key: "b"})
+			c := New(WithBaseURL(url), WithKeyPool(pool), WithRetryPolicy(testPolicy))
+
+			_, _ = c.BroadcastHex(context.Background(), &BroadcastHexRequest{Transaction: "00"})
+
+			if !slices.Equal(keys(), tt.want) {
+				t.Errorf("sent keys %v, want %v", keys(), tt.want)
+			}
+		})
+	}
+}
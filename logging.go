@@ -0,0 +1,136 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WithLogger logs every request at debug level and retries and failovers
+// at warn level.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(o *clientOptions) {
+		o.logger = logger
+	}
+}
+
+// WithLogDecodeErrorBodies makes the logger include up to maxBytes of the
+// response body when a response cannot be decoded.
+func WithLogDecodeErrorBodies(maxBytes int) ClientOption {
+	return func(o *clientOptions) {
+		o.logBodyLimit = maxBytes
+	}
+}
+
+func (c *client) logRequest(ctx context.Context, method string, u *url.URL, status int, size int, duration time.Duration, err error) {
+
+	if c.options.logger == nil {
+		return
+	}
+
+	info, _ := RequestInfoFromContext(ctx)
+
+	attrs := []slog.Attr{
+		slog.String("operation", info.Operation),
+		slog.Int("attempt", info.Attempt),
+		slog.String("method", method),
+		slog.String("url", redactURL(u)),
+		slog.Duration("duration", duration),
+	}
+
+	if err != nil && status == 0 {
+		attrs = append(attrs, slog.Any("error", err))
+	} else {
+		attrs = append(attrs, slog.Int("status", status), slog.Int("bytes", size))
+	}
+
+	if info.Page > 0 {
+		attrs = append(attrs, slog.Int("page", info.Page))
+	}
+
+	c.options.logger.LogAttrs(ctx, slog.LevelDebug, "trongrid request", attrs...)
+}
+
+func (c *client) logRetry(ctx context.Context, call apiCall, attempt int, delay time.Duration, err error) {
+
+	if c.options.logger == nil {
+		return
+	}
+
+	c.options.logger.LogAttrs(ctx, slog.LevelWarn, "trongrid request failed, retrying",
+		slog.String("operation", call.op),
+		slog.Int("attempt", attempt),
+		slog.Duration("delay", delay),
+		slog.Any("error", err),
+	)
+}
+
+func (c *client) logFailover(ctx context.Context, call apiCall, endpoint *endpointState, err error) {
+
+	if c.options.logger == nil {
+		return
+	}
+
+	c.options.logger.LogAttrs(ctx, slog.LevelWarn, "trongrid endpoint failed, failing over",
+		slog.String("operation", call.op),
+		slog.String("endpoint", redactURL(endpoint.base)),
+		slog.Any("error", err),
+	)
+}
+
+// decode unmarshals a response body, logging the body if it cannot be
+// decoded and WithLogDecodeErrorBodies is set.
+func (c *client) decode(ctx context.Context, op string, body []byte, out interface{}) error {
+
+	err := json.Unmarshal(body, out)
+	if err == nil || c.options.logger == nil {
+		return err
+	}
+
+	attrs := []slog.Attr{
+		slog.String("operation", op),
+		slog.Any("error", err),
+	}
+
+	if c.options.logBodyLimit > 0 {
+		dump := body
+		if len(dump) > c.options.logBodyLimit {
+			dump = dump[:c.options.logBodyLimit]
+		}
+
+		attrs = append(attrs, slog.String("body", string(dump)))
+	}
+
+	c.options.logger.LogAttrs(ctx, slog.LevelError, "trongrid response decode failed", attrs...)
+
+	return err
+}
+
+// redactURL returns u with passwords and API key query parameters
+// replaced.
+func redactURL(u *url.URL) string {
+
+	if u == nil {
+		return ""
+	}
+
+	r := *u
+
+	q := r.Query()
+	redacted := false
+	for name := range q {
+		if strings.Contains(strings.ToLower(name), "key") {
+			q.Set(name, "xxxxx")
+			redacted = true
+		}
+	}
+
+	if redacted {
+		r.RawQuery = q.Encode()
+	}
+
+	return r.Redacted()
+}
@@ -0,0 +1,68 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+)
+
+// Doer sends a single HTTP request. *http.Client implements it.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer every request of the client is sent through.
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middlewares to the chain all requests, including
+// cursor pages, are sent through. Every retry and failover attempt passes
+// the chain again. The first middleware is the outermost one.
+func WithMiddleware(middlewares ...Middleware) ClientOption {
+	return func(o *clientOptions) {
+		o.middlewares = append(o.middlewares, middlewares...)
+	}
+}
+
+// RequestInfo describes the logical operation an HTTP request belongs to.
+type RequestInfo struct {
+	// Operation is the client method the request is made for, e.g.
+	// GetAccount, or HealthCheck for endpoint health checks.
+	Operation string
+	// Network is the network the client is configured for.
+	Network Network
+	// Attempt is the 1-based attempt number, counting retries and
+	// failovers.
+	Attempt int
+	// Page is the 1-based index of the page fetched by a transaction
+	// cursor, or 0 for other requests.
+	Page int
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the RequestInfo attached to the context
+// of requests passed to middlewares and of the rate limiter waits made
+// for them.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+func contextWithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// chainMiddlewares wraps doer with middlewares, the first one outermost.
+func chainMiddlewares(doer Doer, middlewares []Middleware) Doer {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		doer = middlewares[i](doer)
+	}
+
+	return doer
+}
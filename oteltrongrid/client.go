@@ -0,0 +1,187 @@
+package oteltrongrid
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheTeaParty/trongrid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type client struct {
+	next        trongrid.Client
+	config      *config
+	instruments *instruments
+}
+
+// NewClient returns a Client that traces and measures every call made to
+// next.
+func NewClient(next trongrid.Client, opts ...Option) trongrid.Client {
+
+	c := newConfig(opts)
+
+	return &client{
+		next:        next,
+		config:      c,
+		instruments: newInstruments(c, "trongrid.client", "client calls to TronGrid"),
+	}
+}
+
+// start opens the span of an operation and returns the function that ends
+// it and records its metrics.
+func (c *client) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, func(error)) {
+
+	attrs = append(attrs,
+		AttributeOperation.String(operation),
+		AttributeNetwork.String(string(c.config.network)),
+	)
+
+	ctx, span := c.instruments.tracer.Start(ctx, "trongrid."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+
+	started := time.Now()
+
+	return ctx, func(err error) {
+
+		metricAttrs := metric.WithAttributes(
+			AttributeOperation.String(operation),
+			AttributeNetwork.String(string(c.config.network)),
+		)
+
+		c.instruments.requests.Add(ctx, 1, metricAttrs)
+		c.instruments.duration.Record(ctx, time.Since(started).Seconds(), metricAttrs)
+
+		if err != nil {
+			code := errorCode(err)
+
+			c.instruments.errors.Add(ctx, 1, metric.WithAttributes(
+				AttributeOperation.String(operation),
+				AttributeNetwork.String(string(c.config.network)),
+				AttributeErrorCode.String(code),
+			))
+
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			span.SetAttributes(AttributeErrorCode.String(code))
+		}
+
+		span.End()
+	}
+}
+
+func (c *client) GetNowBlock(ctx context.Context) (*trongrid.Block, error) {
+	ctx, end := c.start(ctx, "GetNowBlock")
+	block, err := c.next.GetNowBlock(ctx)
+	end(err)
+	return block, err
+}
+
+func (c *client) GetAccountBalance(ctx context.Context, address trongrid.Address, blockNumber uint64, blockHash string) (*trongrid.AccountBalance, error) {
+	ctx, end := c.start(ctx, "GetAccountBalance",
+		AttributeAddress.String(address.String()),
+		AttributeBlockNumber.Int64(int64(blockNumber)))
+	balance, err := c.next.GetAccountBalance(ctx, address, blockNumber, blockHash)
+	end(err)
+	return balance, err
+}
+
+func (c *client) GetBlockByNumber(ctx context.Context, number uint64) (*trongrid.Block, error) {
+	ctx, end := c.start(ctx, "GetBlockByNumber", AttributeBlockNumber.Int64(int64(number)))
+	block, err := c.next.GetBlockByNumber(ctx, number)
+	end(err)
+	return block, err
+}
+
+func (c *client) GetBlock(ctx context.Context, idOrNum string, detail bool) (*trongrid.Block, error) {
+	ctx, end := c.start(ctx, "GetBlock", AttributeBlockID.String(idOrNum))
+	block, err := c.next.GetBlock(ctx, idOrNum, detail)
+	end(err)
+	return block, err
+}
+
+func (c *client) GetBlocksByRange(ctx context.Context, from, to uint64) ([]*trongrid.Block, error) {
+	ctx, end := c.start(ctx, "GetBlocksByRange",
+		AttributeBlockNumber.Int64(int64(from)),
+		AttributeBlockCount.Int64(int64(blockCount(from, to))))
+	blocks, err := c.next.GetBlocksByRange(ctx, from, to)
+	end(err)
+	return blocks, err
+}
+
+func (c *client) GetLatestBlocks(ctx context.Context, n int) ([]*trongrid.Block, error) {
+	ctx, end := c.start(ctx, "GetLatestBlocks", AttributeBlockCount.Int(n))
+	blocks, err := c.next.GetLatestBlocks(ctx, n)
+	end(err)
+	return blocks, err
+}
+
+func (c *client) GetAccount(ctx context.Context, address trongrid.Address) (*trongrid.Account, error) {
+	ctx, end := c.start(ctx, "GetAccount", AttributeAddress.String(address.String()))
+	account, err := c.next.GetAccount(ctx, address)
+	end(err)
+	return account, err
+}
+
+func (c *client) GetAccountTransactions(ctx context.Context, address trongrid.Address, opts ...trongrid.GetAccountTransactionsOption) (*trongrid.GetAccountTransactionsCursor, error) {
+	ctx, end := c.start(ctx, "GetAccountTransactions", AttributeAddress.String(address.String()))
+	cursor, err := c.next.GetAccountTransactions(ctx, address, opts...)
+	end(err)
+	return cursor, err
+}
+
+func (c *client) BroadcastHex(ctx context.Context, req *trongrid.BroadcastHexRequest) (*trongrid.BroadcastHexResponse, error) {
+	ctx, end := c.start(ctx, "BroadcastHex")
+	resp, err := c.next.BroadcastHex(ctx, req)
+	if resp != nil {
+		trace.SpanFromContext(ctx).SetAttributes(AttributeTxID.String(resp.Txid))
+	}
+	end(err)
+	return resp, err
+}
+
+func (c *client) TriggerConstantContract(ctx context.Context, req *trongrid.TriggerConstantContractRequest) (*trongrid.TriggerConstantContractResponse, error) {
+	var attrs []attribute.KeyValue
+	if req != nil {
+		attrs = append(attrs, AttributeAddress.String(req.ContractAddress.String()))
+	}
+
+	ctx, end := c.start(ctx, "TriggerConstantContract", attrs...)
+	resp, err := c.next.TriggerConstantContract(ctx, req)
+	end(err)
+	return resp, err
+}
+
+func (c *client) GetContractTransaction(ctx context.Context, address trongrid.Address, contractType string, opts ...trongrid.GetContractTransactionOption) (*trongrid.GetContractTransactionCursor, error) {
+	ctx, end := c.start(ctx, "GetContractTransaction", AttributeAddress.String(address.String()))
+	cursor, err := c.next.GetContractTransaction(ctx, address, contractType, opts...)
+	end(err)
+	return cursor, err
+}
+
+func (c *client) GetTransactionInfoByID(ctx context.Context, txID string) (*trongrid.GetTransactionInfoByIDResponse, error) {
+	ctx, end := c.start(ctx, "GetTransactionInfoByID", AttributeTxID.String(txID))
+	info, err := c.next.GetTransactionInfoByID(ctx, txID)
+	end(err)
+	return info, err
+}
+
+func (c *client) GetContract(ctx context.Context, address trongrid.Address) (*trongrid.SmartContract, error) {
+	ctx, end := c.start(ctx, "GetContract", AttributeAddress.String(address.String()))
+	contract, err := c.next.GetContract(ctx, address)
+	end(err)
+	return contract, err
+}
+
+// blockCount returns the number of blocks in [from, to), or 0 for an
+// invalid range.
+func blockCount(from, to uint64) uint64 {
+	if to < from {
+		return 0
+	}
+
+	return to - from
+}
@@ -0,0 +1,114 @@
+package oteltrongrid
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TheTeaParty/trongrid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware returns a trongrid.Middleware emitting a span and metrics
+// for every HTTP attempt, including retries, failovers and cursor pages.
+// Its metrics are named trongrid.http.* and count errors reported by the
+// node with a 200 status, such as SIGERROR, by their code.
+func Middleware(opts ...Option) trongrid.Middleware {
+
+	c := newConfig(opts)
+	instruments := newInstruments(c, "trongrid.http", "HTTP requests sent to TronGrid")
+
+	return func(next trongrid.Doer) trongrid.Doer {
+		return trongrid.DoerFunc(func(req *http.Request) (*http.Response, error) {
+
+			info, _ := trongrid.RequestInfoFromContext(req.Context())
+
+			attrs := []attribute.KeyValue{
+				AttributeOperation.String(info.Operation),
+				AttributeNetwork.String(string(info.Network)),
+			}
+
+			spanAttrs := append(attrs,
+				AttributeEndpoint.String(req.URL.Host+req.URL.Path),
+				AttributeAttempt.Int(info.Attempt),
+			)
+
+			if info.Page > 0 {
+				spanAttrs = append(spanAttrs, AttributeCursorPage.Int(info.Page))
+			}
+
+			ctx, span := instruments.tracer.Start(req.Context(), "trongrid.http "+info.Operation,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(spanAttrs...))
+			defer span.End()
+
+			started := time.Now()
+
+			resp, err := next.Do(req.WithContext(ctx))
+
+			instruments.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+			instruments.duration.Record(ctx, time.Since(started).Seconds(), metric.WithAttributes(attrs...))
+
+			if err != nil {
+				instruments.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, AttributeErrorCode.String("transport"))...))
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+				return nil, err
+			}
+
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+			if resp.StatusCode >= http.StatusBadRequest {
+				instruments.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, AttributeErrorCode.String(strconv.Itoa(resp.StatusCode)))...))
+				span.SetStatus(codes.Error, resp.Status)
+				return resp, nil
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if err != nil {
+				return resp, nil
+			}
+
+			if code := nodeErrorCode(body); code != "" {
+				instruments.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, AttributeErrorCode.String(code))...))
+				span.SetAttributes(AttributeErrorCode.String(code))
+				span.SetStatus(codes.Error, code)
+			}
+
+			return resp, nil
+		})
+	}
+}
+
+// nodeErrorCode returns the error code of a successful HTTP response that
+// reports a failure in its body: the node response code, e.g. SIGERROR,
+// or "error" for a TronGrid "Error" field. It is empty otherwise.
+func nodeErrorCode(body []byte) string {
+
+	var errBody struct {
+		Error string `json:"Error"`
+		Code  string `json:"code"`
+	}
+
+	if json.Unmarshal(body, &errBody) != nil {
+		return ""
+	}
+
+	if errBody.Code != "" && errBody.Code != trongrid.CodeSuccess {
+		return errBody.Code
+	}
+
+	if errBody.Error != "" {
+		return "error"
+	}
+
+	return ""
+}
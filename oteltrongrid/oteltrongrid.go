@@ -0,0 +1,136 @@
+// Package oteltrongrid instruments a trongrid.Client with OpenTelemetry
+// tracing and metrics.
+//
+// NewClient wraps the Client and emits a span per method call. Cursor
+// pages are fetched after the cursor is returned, so their spans come from
+// Middleware, which should be installed on the underlying client with
+// trongrid.WithMiddleware. NewClient records trongrid.client.* metrics per
+// call and Middleware trongrid.http.* metrics per HTTP attempt, so both
+// can be installed together. RateLimiter records the time spent waiting
+// on the client rate limiter.
+package oteltrongrid
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/TheTeaParty/trongrid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/TheTeaParty/trongrid/oteltrongrid"
+
+// Attribute keys set on spans and metrics.
+const (
+	AttributeOperation   = attribute.Key("trongrid.operation")
+	AttributeNetwork     = attribute.Key("trongrid.network")
+	AttributeEndpoint    = attribute.Key("trongrid.endpoint")
+	AttributeAddress     = attribute.Key("trongrid.address")
+	AttributeTxID        = attribute.Key("trongrid.tx_id")
+	AttributeBlockNumber = attribute.Key("trongrid.block_number")
+	AttributeBlockID     = attribute.Key("trongrid.block_id")
+	AttributeBlockCount  = attribute.Key("trongrid.block_count")
+	AttributeCursorPage  = attribute.Key("trongrid.cursor.page")
+	AttributeAttempt     = attribute.Key("trongrid.attempt")
+	AttributeErrorCode   = attribute.Key("trongrid.error.code")
+)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	network        trongrid.Network
+}
+
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider. The global one is used by
+// default.
+func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tracerProvider
+	}
+}
+
+// WithMeterProvider sets the meter provider. The global one is used by
+// default.
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = meterProvider
+	}
+}
+
+// WithNetwork sets the network reported by NewClient spans. Middleware
+// takes the network from the request itself.
+func WithNetwork(network trongrid.Network) Option {
+	return func(c *config) {
+		c.network = network
+	}
+}
+
+func newConfig(opts []Option) *config {
+
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// instruments holds the tracer and the request metrics of the client
+// wrapper or of the middleware.
+type instruments struct {
+	tracer trace.Tracer
+
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// newInstruments creates the request metrics under prefix, which is
+// "trongrid.client" for calls to the client wrapper and "trongrid.http"
+// for HTTP attempts seen by the middleware, so that both can be installed
+// without counting the same request twice under one name.
+func newInstruments(c *config, prefix, what string) *instruments {
+
+	meter := c.meterProvider.Meter(instrumentationName)
+
+	i := &instruments{
+		tracer: c.tracerProvider.Tracer(instrumentationName),
+	}
+
+	// Instrument creation only fails on invalid names; the no-op
+	// instruments returned alongside the error are still usable.
+	i.requests, _ = meter.Int64Counter(prefix+".requests",
+		metric.WithDescription("Number of "+what+"."),
+		metric.WithUnit("{request}"))
+	i.errors, _ = meter.Int64Counter(prefix+".errors",
+		metric.WithDescription("Number of failed "+what+" by error code."),
+		metric.WithUnit("{request}"))
+	i.duration, _ = meter.Float64Histogram(prefix+".duration",
+		metric.WithDescription("Duration of "+what+"."),
+		metric.WithUnit("s"))
+
+	return i
+}
+
+func errorCode(err error) string {
+
+	var apiErr *trongrid.APIError
+	if !errors.As(err, &apiErr) {
+		return "transport"
+	}
+
+	if apiErr.Code != "" {
+		return apiErr.Code
+	}
+
+	return strconv.Itoa(apiErr.StatusCode)
+}
@@ -0,0 +1,227 @@
+package oteltrongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TheTeaParty/trongrid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var usdt = trongrid.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+
+// fakeClient answers GetNowBlock and fails TriggerConstantContract. Other
+// methods are not implemented.
+type fakeClient struct {
+	trongrid.Client
+}
+
+func (fakeClient) GetNowBlock(ctx context.Context) (*trongrid.Block, error) {
+	return &trongrid.Block{BlockID: "00"}, nil
+}
+
+func (fakeClient) TriggerConstantContract(ctx context.Context, req *trongrid.TriggerConstantContractRequest) (*trongrid.TriggerConstantContractResponse, error) {
+	if req == nil {
+		return nil, errors.New("nil request")
+	}
+
+	return nil, &trongrid.APIError{StatusCode: http.StatusOK, Code: trongrid.CodeContractValidateError}
+}
+
+type providers struct {
+	spans  *tracetest.SpanRecorder
+	reader *sdkmetric.ManualReader
+	opts   []Option
+}
+
+func newProviders() *providers {
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	return &providers{
+		spans:  spans,
+		reader: reader,
+		opts: []Option{
+			WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+			WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+		},
+	}
+}
+
+// sums returns the values of the counter called name by the value of the
+// attribute key.
+func (p *providers) sums(t *testing.T, name string, key attribute.Key) map[string]int64 {
+	t.Helper()
+
+	var rm metricdata.ResourceMetrics
+	err := p.reader.Collect(context.Background(), &rm)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sums := make(map[string]int64)
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+
+			for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+				value, _ := point.Attributes.Value(key)
+				sums[value.Emit()] += point.Value
+			}
+		}
+	}
+
+	return sums
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+
+	return ""
+}
+
+func TestClient(t *testing.T) {
+
+	p := newProviders()
+	c := NewClient(fakeClient{}, append(p.opts, WithNetwork(trongrid.NetworkNileTestnet))...)
+
+	ctx := context.Background()
+
+	_, err := c.GetNowBlock(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.TriggerConstantContract(ctx, &trongrid.TriggerConstantContractRequest{ContractAddress: usdt})
+	if err == nil {
+		t.Fatal("TriggerConstantContract succeeded")
+	}
+
+	// A nil request reaches the wrapped client rather than panicking.
+	_, err = c.TriggerConstantContract(ctx, nil)
+	if err == nil || err.Error() != "nil request" {
+		t.Fatalf("TriggerConstantContract(nil) error = %v", err)
+	}
+
+	spans := p.spans.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	tests := []struct {
+		name      string
+		status    codes.Code
+		address   string
+		errorCode string
+	}{
+		{name: "trongrid.GetNowBlock", status: codes.Unset},
+		{name: "trongrid.TriggerConstantContract", status: codes.Error, address: usdt.String(), errorCode: trongrid.CodeContractValidateError},
+		{name: "trongrid.TriggerConstantContract", status: codes.Error, errorCode: "transport"},
+	}
+
+	for i, tt := range tests {
+		span := spans[i]
+
+		if span.Name() != tt.name || span.SpanKind() != trace.SpanKindClient {
+			t.Errorf("span %d = %s %s, want client span %s", i, span.SpanKind(), span.Name(), tt.name)
+		}
+
+		if span.Status().Code != tt.status {
+			t.Errorf("span %s status = %s, want %s", span.Name(), span.Status().Code, tt.status)
+		}
+
+		if got := spanAttribute(span, AttributeNetwork); got != string(trongrid.NetworkNileTestnet) {
+			t.Errorf("span %s network = %q", span.Name(), got)
+		}
+
+		if got := spanAttribute(span, AttributeAddress); got != tt.address {
+			t.Errorf("span %s address = %q, want %q", span.Name(), got, tt.address)
+		}
+
+		if got := spanAttribute(span, AttributeErrorCode); got != tt.errorCode {
+			t.Errorf("span %s error code = %q, want %q", span.Name(), got, tt.errorCode)
+		}
+	}
+
+	requests := p.sums(t, "trongrid.client.requests", AttributeOperation)
+	if requests["GetNowBlock"] != 1 || requests["TriggerConstantContract"] != 2 {
+		t.Errorf("requests = %v", requests)
+	}
+
+	errs := p.sums(t, "trongrid.client.errors", AttributeErrorCode)
+	if errs[trongrid.CodeContractValidateError] != 1 || errs["transport"] != 1 || len(errs) != 2 {
+		t.Errorf("errors = %v", errs)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+
+	responses := []struct {
+		status int
+		body   string
+	}{
+		{http.StatusOK, `{"result":true,"txid":"00"}`},
+		{http.StatusOK, `{"code":"SIGERROR","message":""}`},
+		{http.StatusServiceUnavailable, ``},
+	}
+
+	n := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(responses[n].status)
+		fmt.Fprint(w, responses[n].body)
+		n++
+	}))
+	defer server.Close()
+
+	p := newProviders()
+	c := trongrid.New(
+		trongrid.WithBaseURL(server.URL),
+		trongrid.WithMiddleware(Middleware(p.opts...)),
+	)
+
+	for range responses {
+		_, _ = c.BroadcastHex(context.Background(), &trongrid.BroadcastHexRequest{Transaction: "00"})
+	}
+
+	spans := p.spans.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	for i, want := range []codes.Code{codes.Unset, codes.Error, codes.Error} {
+		if spans[i].Name() != "trongrid.http BroadcastHex" || spans[i].Status().Code != want {
+			t.Errorf("span %d = %s with status %s, want status %s", i, spans[i].Name(), spans[i].Status().Code, want)
+		}
+	}
+
+	if got := spanAttribute(spans[1], AttributeErrorCode); got != trongrid.CodeSigError {
+		t.Errorf("error code = %q, want %s", got, trongrid.CodeSigError)
+	}
+
+	requests := p.sums(t, "trongrid.http.requests", AttributeOperation)
+	if requests["BroadcastHex"] != 3 {
+		t.Errorf("requests = %v", requests)
+	}
+
+	errs := p.sums(t, "trongrid.http.errors", AttributeErrorCode)
+	if errs[trongrid.CodeSigError] != 1 || errs["503"] != 1 || len(errs) != 2 {
+		t.Errorf("errors = %v", errs)
+	}
+}
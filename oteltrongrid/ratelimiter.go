@@ -0,0 +1,52 @@
+package oteltrongrid
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheTeaParty/trongrid"
+	"go.opentelemetry.io/otel/metric"
+)
+
+type rateLimiter struct {
+	next   trongrid.RateLimiter
+	config *config
+	wait   metric.Float64Histogram
+}
+
+// RateLimiter returns a trongrid.RateLimiter that records the time spent
+// waiting on next, by operation for waits made by the HTTP client. Pass
+// it to trongrid.WithRateLimiter.
+func RateLimiter(next trongrid.RateLimiter, opts ...Option) trongrid.RateLimiter {
+
+	c := newConfig(opts)
+
+	r := &rateLimiter{
+		next:   next,
+		config: c,
+	}
+
+	r.wait, _ = c.meterProvider.Meter(instrumentationName).Float64Histogram("trongrid.client.rate_limiter.wait",
+		metric.WithDescription("Time spent waiting on the rate limiter."),
+		metric.WithUnit("s"))
+
+	return r
+}
+
+func (r *rateLimiter) Wait(ctx context.Context) error {
+
+	started := time.Now()
+	err := r.next.Wait(ctx)
+
+	attrs := metric.WithAttributes(AttributeNetwork.String(string(r.config.network)))
+	if info, ok := trongrid.RequestInfoFromContext(ctx); ok {
+		attrs = metric.WithAttributes(
+			AttributeNetwork.String(string(info.Network)),
+			AttributeOperation.String(info.Operation),
+		)
+	}
+
+	r.wait.Record(ctx, time.Since(started).Seconds(), attrs)
+
+	return err
+}
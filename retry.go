@@ -0,0 +1,129 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Read endpoints and
+// cursor pages are retried on transport errors, rate limiting and 5xx
+// responses. BroadcastHex is only retried when the error proves the
+// transaction was not accepted by the node.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first
+	// one. Values below 2 disable retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between attempts. A larger Retry-After
+	// sent by the server still takes precedence.
+	MaxBackoff time.Duration
+	// Multiplier grows the delay after every attempt. Values below 1
+	// default to 2.
+	Multiplier float64
+}
+
+// DefaultRetryPolicy returns the policy used by WithRetryPolicy callers that
+// do not need fine-tuning.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// backoff returns the jittered delay before the given retry attempt,
+// honouring the Retry-After carried by err.
+func (p RetryPolicy) backoff(attempt int, err error) time.Duration {
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	d := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		d *= multiplier
+	}
+
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+
+	delay := time.Duration(d / 2)
+	if delay > 0 {
+		delay += rand.N(delay)
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+		delay = apiErr.RetryAfter
+	}
+
+	return delay
+}
+
+// isRetryable reports whether err is a transient failure.
+func isRetryable(err error) bool {
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		// Transport errors.
+		return true
+	}
+
+	if errors.Is(err, ErrRateLimited) || apiErr.Code == CodeServerBusy {
+		return true
+	}
+
+	switch apiErr.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isNotAccepted reports whether err proves the request never reached the
+// node or was rejected before being processed, so that even a broadcast
+// can safely be sent again.
+func isNotAccepted(err error) bool {
+
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+
+	switch apiErr.Code {
+	case CodeServerBusy, CodeNoConnection, CodeNotEnoughEffectiveConnection:
+		return true
+	}
+
+	return false
+}
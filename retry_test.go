@@ -0,0 +1,268 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const nowBlockJSON = `{"blockID":"00","block_header":{"raw_data":{"number":1}}}`
+
+// testPolicy retries quickly so that tests do not wait on backoffs.
+var testPolicy = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond, Multiplier: 2}
+
+// countingServer serves every request with handle, passing the 1-based
+// index of the request, and counts the requests it received.
+func countingServer(t *testing.T, handle func(w http.ResponseWriter, r *http.Request, n int)) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var count atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handle(w, r, int(count.Add(1)))
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &count
+}
+
+// dropConnection closes the connection without answering, leaving the
+// client unsure whether the request was processed.
+func dropConnection(t *testing.T, w http.ResponseWriter) {
+
+	conn, _, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	conn.Close()
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempt  int
+		err      error
+		min, max time.Duration
+	}{
+		{
+			name:    "first",
+			policy:  RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 2},
+			attempt: 1,
+			min:     50 * time.Millisecond,
+			max:     100 * time.Millisecond,
+		},
+		{
+			name:    "grown",
+			policy:  RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 3},
+			attempt: 3,
+			min:     450 * time.Millisecond,
+			max:     900 * time.Millisecond,
+		},
+		{
+			name:    "capped",
+			policy:  RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 150 * time.Millisecond, Multiplier: 2},
+			attempt: 5,
+			min:     75 * time.Millisecond,
+			max:     150 * time.Millisecond,
+		},
+		{
+			name:    "zero multiplier",
+			policy:  RetryPolicy{InitialBackoff: 100 * time.Millisecond},
+			attempt: 3,
+			min:     200 * time.Millisecond,
+			max:     400 * time.Millisecond,
+		},
+		{
+			name:    "shrinking multiplier",
+			policy:  RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 0.5},
+			attempt: 2,
+			min:     100 * time.Millisecond,
+			max:     200 * time.Millisecond,
+		},
+		{
+			name:    "retry after",
+			policy:  RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 100 * time.Millisecond, Multiplier: 2},
+			attempt: 1,
+			err:     &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: 5 * time.Second},
+			min:     5 * time.Second,
+			max:     5 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			for range 20 {
+				d := tt.policy.backoff(tt.attempt, tt.err)
+				if d < tt.min || d > tt.max {
+					t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, d, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryMaxAttempts(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		failures int
+		policy   RetryPolicy
+		requests int32
+		wantErr  bool
+	}{
+		{name: "recovers", failures: 2, policy: testPolicy, requests: 3},
+		{name: "gives up", failures: 10, policy: testPolicy, requests: 3, wantErr: true},
+		{name: "no retries", failures: 10, policy: RetryPolicy{MaxAttempts: 1}, requests: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			server, count := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+				if n <= tt.failures {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				fmt.Fprint(w, nowBlockJSON)
+			})
+
+			c := New(WithBaseURL(server.URL), WithRetryPolicy(tt.policy))
+
+			_, err := c.GetNowBlock(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetNowBlock error = %v, want error %t", err, tt.wantErr)
+			}
+
+			if count.Load() != tt.requests {
+				t.Errorf("sent %d requests, want %d", count.Load(), tt.requests)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+
+	server, count := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		fmt.Fprint(w, nowBlockJSON)
+	})
+
+	c := New(WithBaseURL(server.URL), WithRetryPolicy(testPolicy))
+
+	started := time.Now()
+
+	_, err := c.GetNowBlock(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if elapsed := time.Since(started); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least the 1s Retry-After", elapsed)
+	}
+
+	if count.Load() != 2 {
+		t.Errorf("sent %d requests, want 2", count.Load())
+	}
+}
+
+func TestBroadcastRetry(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		fail     func(t *testing.T, w http.ResponseWriter)
+		requests int32
+	}{
+		{
+			name:     "dropped connection",
+			fail:     dropConnection,
+			requests: 1,
+		},
+		{
+			name: "server error",
+			fail: func(t *testing.T, w http.ResponseWriter) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			requests: 1,
+		},
+		{
+			name: "bad gateway",
+			fail: func(t *testing.T, w http.ResponseWriter) {
+				w.WriteHeader(http.StatusBadGateway)
+			},
+			requests: 1,
+		},
+		{
+			// The node did not accept the transaction, so it is resent.
+			name: "server busy",
+			fail: func(t *testing.T, w http.ResponseWriter) {
+				fmt.Fprint(w, `{"result":false,"code":"SERVER_BUSY","message":""}`)
+			},
+			requests: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			server, count := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+				if n == 1 {
+					tt.fail(t, w)
+					return
+				}
+
+				fmt.Fprint(w, `{"result":true,"txid":"00"}`)
+			})
+
+			c := New(WithBaseURL(server.URL), WithRetryPolicy(testPolicy))
+
+			_, err := c.BroadcastHex(context.Background(), &BroadcastHexRequest{Transaction: "00"})
+			if tt.requests == 1 && err == nil {
+				t.Error("BroadcastHex succeeded after resending")
+			}
+
+			if count.Load() != tt.requests {
+				t.Errorf("sent %d requests, want %d", count.Load(), tt.requests)
+			}
+		})
+	}
+}
+
+func TestRetryContextCanceled(t *testing.T) {
+
+	server, count := countingServer(t, func(w http.ResponseWriter, r *http.Request, n int) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	policy := RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Minute, Multiplier: 2}
+	c := New(WithBaseURL(server.URL), WithRetryPolicy(policy))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetNowBlock(ctx)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GetNowBlock error = %v, want the 503", err)
+	}
+
+	if count.Load() != 1 {
+		t.Errorf("sent %d requests, want 1", count.Load())
+	}
+}
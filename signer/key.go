@@ -0,0 +1,139 @@
+package signer
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// SignatureLength is the length of a TRON signature: r, s and the
+// recovery id.
+const SignatureLength = 65
+
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// PrivateKey is a secp256k1 private key controlling a TRON address.
+type PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// GeneratePrivateKey returns a new random private key.
+func GeneratePrivateKey() (*PrivateKey, error) {
+
+	b := make([]byte, 32)
+	for {
+		_, err := rand.Read(b)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := PrivateKeyFromBytes(b)
+		if err == nil {
+			return key, nil
+		}
+	}
+}
+
+// PrivateKeyFromHex parses a 32-byte hex-encoded private key.
+func PrivateKeyFromHex(s string) (*PrivateKey, error) {
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	return PrivateKeyFromBytes(b)
+}
+
+// PrivateKeyFromBytes returns the private key of a 32-byte scalar.
+func PrivateKeyFromBytes(b []byte) (*PrivateKey, error) {
+
+	if len(b) != 32 {
+		return nil, fmt.Errorf("invalid private key length %d", len(b))
+	}
+
+	var scalar secp256k1.ModNScalar
+	overflow := scalar.SetByteSlice(b)
+	if overflow || scalar.IsZero() {
+		return nil, errors.New("invalid private key: out of range")
+	}
+
+	return &PrivateKey{key: secp256k1.NewPrivateKey(&scalar)}, nil
+}
+
+// Hex returns the hex encoding of the private key.
+func (k *PrivateKey) Hex() string {
+	return hex.EncodeToString(k.key.Serialize())
+}
+
+// Address returns the TRON address controlled by the key.
+func (k *PrivateKey) Address() trongrid.Address {
+	return publicKeyAddress(k.key.PubKey())
+}
+
+// Sign signs a 32-byte digest and returns the 65-byte signature in the
+// r || s || v form used by TRON, v being the recovery id 0 or 1.
+// Signatures are deterministic (RFC 6979).
+func (k *PrivateKey) Sign(digest []byte) ([]byte, error) {
+
+	if len(digest) != 32 {
+		return nil, fmt.Errorf("invalid digest length %d", len(digest))
+	}
+
+	// SignCompact returns 27 + recovery id || r || s for an uncompressed
+	// public key.
+	compact := ecdsa.SignCompact(k.key, digest, false)
+
+	sig := make([]byte, SignatureLength)
+	copy(sig, compact[1:])
+	sig[64] = compact[0] - 27
+
+	return sig, nil
+}
+
+// RecoverAddress returns the address whose key produced sig over digest.
+func RecoverAddress(digest, sig []byte) (trongrid.Address, error) {
+
+	if len(sig) != SignatureLength || len(digest) != 32 {
+		return trongrid.Address{}, ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v >= 27 {
+		v -= 27
+	}
+
+	if v > 1 {
+		return trongrid.Address{}, ErrInvalidSignature
+	}
+
+	compact := make([]byte, SignatureLength)
+	compact[0] = 27 + v
+	copy(compact[1:], sig[:64])
+
+	pub, _, err := ecdsa.RecoverCompact(compact, digest)
+	if err != nil {
+		return trongrid.Address{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return publicKeyAddress(pub), nil
+}
+
+// publicKeyAddress derives the address of a public key: 0x41 followed by
+// the last 20 bytes of the Keccak-256 hash of the uncompressed key.
+func publicKeyAddress(pub *secp256k1.PublicKey) trongrid.Address {
+
+	h := sha3.NewLegacyKeccak256()
+	h.Write(pub.SerializeUncompressed()[1:])
+	sum := h.Sum(nil)
+
+	address, _ := trongrid.AddressFromBytes(sum[12:])
+
+	return address
+}
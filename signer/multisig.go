@@ -0,0 +1,223 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+)
+
+var (
+	ErrThresholdNotMet   = errors.New("permission threshold not met")
+	ErrSignerMismatch    = errors.New("signature does not match signer address")
+	ErrPermissionChanged = errors.New("transaction is already signed for another permission")
+)
+
+// Signer signs 32-byte transaction digests on behalf of an address. The
+// key may live in process, as with PrivateKey, or in an external key store.
+type Signer interface {
+	Address() trongrid.Address
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+}
+
+// SignDigest implements Signer.
+func (k *PrivateKey) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	return k.Sign(digest)
+}
+
+// SignFunc signs a digest, typically by calling an external key store.
+type SignFunc func(ctx context.Context, digest []byte) ([]byte, error)
+
+type externalSigner struct {
+	address trongrid.Address
+	sign    SignFunc
+}
+
+// NewExternalSigner returns a Signer for address that delegates signing to
+// sign. Signatures are checked to be made by address, so a misconfigured
+// key store fails early rather than at broadcast.
+func NewExternalSigner(address trongrid.Address, sign SignFunc) Signer {
+	return &externalSigner{address: address, sign: sign}
+}
+
+func (s *externalSigner) Address() trongrid.Address {
+	return s.address
+}
+
+func (s *externalSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+
+	sig, err := s.sign(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := RecoverAddress(digest, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if signer != s.address {
+		return nil, fmt.Errorf("%w: signed by %s, want %s", ErrSignerMismatch, signer, s.address)
+	}
+
+	return sig, nil
+}
+
+// Sign signs tx with signer and appends the signature to it.
+func Sign(ctx context.Context, tx *core.Transaction, signer Signer) error {
+
+	txID, err := TransactionID(tx)
+	if err != nil {
+		return err
+	}
+
+	sig, err := signer.SignDigest(ctx, txID)
+	if err != nil {
+		return err
+	}
+
+	tx.Signature = append(tx.Signature, sig)
+
+	return nil
+}
+
+// MultiSigner signs transactions for an account permission with several
+// signers, as set up with the account's owner or active permissions.
+type MultiSigner struct {
+	permission trongrid.Permission
+	signers    []Signer
+}
+
+// NewMultiSigner returns a MultiSigner for permission, typically one of
+// Account.ActivePermission. Signers are asked in order until the weights
+// of the collected signatures reach the threshold, so the preferred ones
+// should come first.
+func NewMultiSigner(permission trongrid.Permission, signers ...Signer) *MultiSigner {
+	return &MultiSigner{permission: permission, signers: signers}
+}
+
+// Permission returns the permission transactions are signed for.
+func (m *MultiSigner) Permission() trongrid.Permission {
+	return m.permission
+}
+
+// SignTransaction sets the permission id of the contracts of an unsigned
+// tx, then collects signatures until the permission threshold is met.
+// Signatures already on tx count towards the threshold and their signers
+// are not asked again. The result is verified with VerifyTransaction, so
+// a nil error means tx can be broadcast.
+func (m *MultiSigner) SignTransaction(ctx context.Context, tx *core.Transaction) error {
+
+	if tx.GetRawData() == nil {
+		return ErrNoRawData
+	}
+
+	for _, contract := range tx.RawData.Contract {
+		if contract.PermissionId == int32(m.permission.Id) {
+			continue
+		}
+
+		if len(tx.Signature) > 0 {
+			return ErrPermissionChanged
+		}
+
+		contract.PermissionId = int32(m.permission.Id)
+	}
+
+	txID, err := TransactionID(tx)
+	if err != nil {
+		return err
+	}
+
+	signed, weight, err := signatureWeights(txID, tx.Signature, &m.permission)
+	if err != nil {
+		return err
+	}
+
+	for _, signer := range m.signers {
+		if weight >= m.permission.Threshold {
+			break
+		}
+
+		address := signer.Address()
+		if signed[address] || m.permission.Weight(address) == 0 {
+			continue
+		}
+
+		sig, err := signer.SignDigest(ctx, txID)
+		if err != nil {
+			return fmt.Errorf("signer %s: %w", address, err)
+		}
+
+		tx.Signature = append(tx.Signature, sig)
+		signed[address] = true
+		weight += m.permission.Weight(address)
+	}
+
+	return VerifyTransaction(tx, m.permission)
+}
+
+// VerifyTransaction checks that the signatures of tx are valid, made by
+// distinct keys of permission, and that their weights reach its
+// threshold. It also checks that the permission may sign the contracts
+// of tx.
+func VerifyTransaction(tx *core.Transaction, permission trongrid.Permission) error {
+
+	txID, err := TransactionID(tx)
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range tx.RawData.Contract {
+		if contract.PermissionId != int32(permission.Id) {
+			return fmt.Errorf("contract permission id %d, want %d", contract.PermissionId, permission.Id)
+		}
+
+		if !permission.Allows(int32(contract.Type)) {
+			return fmt.Errorf("permission %q does not allow %s", permission.PermissionName, contract.Type)
+		}
+	}
+
+	_, weight, err := signatureWeights(txID, tx.Signature, &permission)
+	if err != nil {
+		return err
+	}
+
+	if weight < permission.Threshold {
+		return fmt.Errorf("%w: weight %d of %d", ErrThresholdNotMet, weight, permission.Threshold)
+	}
+
+	return nil
+}
+
+// signatureWeights recovers the signers of sigs and returns them with the
+// sum of their weights. Signatures by keys outside the permission and
+// duplicate signers are rejected, as the node does.
+func signatureWeights(txID []byte, sigs [][]byte, permission *trongrid.Permission) (map[trongrid.Address]bool, int, error) {
+
+	signed := make(map[trongrid.Address]bool, len(sigs))
+	weight := 0
+
+	for i, sig := range sigs {
+		address, err := RecoverAddress(txID, sig)
+		if err != nil {
+			return nil, 0, fmt.Errorf("signature %d: %w", i, err)
+		}
+
+		if signed[address] {
+			return nil, 0, fmt.Errorf("signature %d: duplicate signer %s", i, address)
+		}
+
+		w := permission.Weight(address)
+		if w == 0 {
+			return nil, 0, fmt.Errorf("signature %d: %s is not a key of permission %q", i, address, permission.PermissionName)
+		}
+
+		signed[address] = true
+		weight += w
+	}
+
+	return signed, weight, nil
+}
@@ -0,0 +1,114 @@
+// Package signer signs TRON transactions offline with secp256k1 keys and
+// produces the hex BroadcastHex expects.
+//
+// The transaction id is the SHA-256 hash of the serialized raw data and is
+// what gets signed. Signing is deterministic (RFC 6979): the same key and
+// transaction always produce the same signature.
+package signer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// Field numbers of protocol.Transaction.
+const (
+	transactionRawDataField   = 1
+	transactionSignatureField = 2
+)
+
+var ErrNoRawData = errors.New("transaction has no raw data")
+
+// RawDataBytes serializes the raw data of tx.
+func RawDataBytes(tx *core.Transaction) ([]byte, error) {
+
+	if tx.GetRawData() == nil {
+		return nil, ErrNoRawData
+	}
+
+	return proto.MarshalOptions{Deterministic: true}.Marshal(tx.GetRawData())
+}
+
+// TxID returns the id of a transaction given its serialized raw data.
+func TxID(rawData []byte) []byte {
+	sum := sha256.Sum256(rawData)
+	return sum[:]
+}
+
+// TransactionID returns the id of tx.
+func TransactionID(tx *core.Transaction) ([]byte, error) {
+
+	rawData, err := RawDataBytes(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return TxID(rawData), nil
+}
+
+// SignTransaction signs tx with key and appends the signature to it.
+func SignTransaction(tx *core.Transaction, key *PrivateKey) error {
+
+	txID, err := TransactionID(tx)
+	if err != nil {
+		return err
+	}
+
+	sig, err := key.Sign(txID)
+	if err != nil {
+		return err
+	}
+
+	tx.Signature = append(tx.Signature, sig)
+
+	return nil
+}
+
+// EncodeTransaction returns the hex encoding of tx as BroadcastHex expects.
+func EncodeTransaction(tx *core.Transaction) (string, error) {
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(tx)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// SignRawDataHex signs a transaction given the raw_data_hex returned by the
+// node, e.g. by /wallet/createtransaction, and returns the transaction id
+// and the hex of the signed transaction. The raw data bytes are kept
+// exactly as given so the id matches the one computed by the node.
+func SignRawDataHex(rawDataHex string, key *PrivateKey) (txID string, signedHex string, err error) {
+
+	rawData, err := hex.DecodeString(rawDataHex)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid raw data hex: %w", err)
+	}
+
+	err = proto.Unmarshal(rawData, &core.TransactionRaw{})
+	if err != nil {
+		return "", "", fmt.Errorf("invalid raw data: %w", err)
+	}
+
+	id := TxID(rawData)
+
+	sig, err := key.Sign(id)
+	if err != nil {
+		return "", "", err
+	}
+
+	var b []byte
+	b = protowire.AppendTag(b, transactionRawDataField, protowire.BytesType)
+	b = protowire.AppendBytes(b, rawData)
+	b = protowire.AppendTag(b, transactionSignatureField, protowire.BytesType)
+	b = protowire.AppendBytes(b, sig)
+
+	return hex.EncodeToString(id), hex.EncodeToString(b), nil
+}
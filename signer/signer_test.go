@@ -0,0 +1,244 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Private key 1, whose Ethereum address 0x7e5f...5bdf is well known.
+const testKeyHex = "0000000000000000000000000000000000000000000000000000000000000001"
+
+// testRawDataHex is a TransferContract of 1 TRX from the address of
+// testKeyHex to TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t.
+const (
+	testRawDataHex = "0a0212342208010203040506070840e0a499ffbc315a67080112630a2d747970652e676f6f676c65617069732e636f6d2f70726f746f636f6c2e5472616e73666572436f6e747261637412320a15417e5f4552091a69125d5dfcb7b8c2659029395bdf121541a614f803b6fd780986a42c78ec9c7f77e6ded13c18c0843d7080d095ffbc31"
+	testTxID       = "974f5b28ab951e7a72fd4bcaa88f37192bf7fae74dbf445e42175e262585378f"
+	testTxSig      = "3ded1c7ed3b318c6eb661b42e79e64a338470fd412439363b8ff91bb19b838e02fb8fd45e9378ac8a20a89b46a68721cf6c86ba43e35e112a3a8c0ffd72d10b000"
+)
+
+func mustKey(t *testing.T, s string) *PrivateKey {
+	t.Helper()
+
+	key, err := PrivateKeyFromHex(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func testTransaction(t *testing.T) *core.Transaction {
+	t.Helper()
+
+	param, err := anypb.New(&core.TransferContract{
+		OwnerAddress: mustKey(t, testKeyHex).Address().Bytes(),
+		ToAddress:    trongrid.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t").Bytes(),
+		Amount:       1_000_000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &core.Transaction{RawData: &core.TransactionRaw{
+		RefBlockBytes: []byte{0x12, 0x34},
+		RefBlockHash:  []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		Expiration:    1700000060000,
+		Timestamp:     1700000000000,
+		Contract: []*core.Transaction_Contract{
+			{Type: core.Transaction_Contract_TransferContract, Parameter: param},
+		},
+	}}
+}
+
+func TestPrivateKeyAddress(t *testing.T) {
+
+	tests := []struct {
+		key    string
+		hex    string
+		base58 string
+	}{
+		{testKeyHex, "417e5f4552091a69125d5dfcb7b8c2659029395bdf", "TMVQGm1qAQYVdetCeGRRkTWYYrLXuHK2HC"},
+		{"da146374a75310b9666e834ee4ad0866d6f4035967bfc76217c5a495fff9f0d0", "41928c9af0651632157ef27a2cf17ca72c575a4d21", "TPL66VK2gCXNCD7EJg9pgJRfqcRazjhUZY"},
+	}
+
+	for _, tt := range tests {
+		address := mustKey(t, tt.key).Address()
+
+		if address.Hex() != tt.hex {
+			t.Errorf("Address(%s).Hex() = %s, want %s", tt.key, address.Hex(), tt.hex)
+		}
+
+		if address.String() != tt.base58 {
+			t.Errorf("Address(%s).String() = %s, want %s", tt.key, address, tt.base58)
+		}
+	}
+}
+
+func TestRawDataBytesAndTxID(t *testing.T) {
+
+	tx := testTransaction(t)
+
+	raw, err := RawDataBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := hex.EncodeToString(raw); got != testRawDataHex {
+		t.Errorf("RawDataBytes = %s, want %s", got, testRawDataHex)
+	}
+
+	if got := hex.EncodeToString(TxID(raw)); got != testTxID {
+		t.Errorf("TxID = %s, want %s", got, testTxID)
+	}
+
+	id, err := TransactionID(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := hex.EncodeToString(id); got != testTxID {
+		t.Errorf("TransactionID = %s, want %s", got, testTxID)
+	}
+}
+
+func TestSign(t *testing.T) {
+
+	// The first two are the RFC 6979 secp256k1 vectors for key 1 over
+	// SHA-256 of the message, with low S.
+	tests := []struct {
+		name   string
+		digest []byte
+		sig    string
+	}{
+		{
+			name:   "Satoshi Nakamoto",
+			digest: sha256Sum("Satoshi Nakamoto"),
+			sig:    "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d82442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e501",
+		},
+		{
+			name:   "tears in rain",
+			digest: sha256Sum("All those moments will be lost in time, like tears in rain. Time to die..."),
+			sig:    "8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc2100",
+		},
+		{
+			name:   "transaction",
+			digest: mustHex(t, testTxID),
+			sig:    testTxSig,
+		},
+	}
+
+	key := mustKey(t, testKeyHex)
+	halfOrder := new(big.Int).Rsh(secp256k1.Params().N, 1)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			sig, err := key.Sign(tt.digest)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := hex.EncodeToString(sig); got != tt.sig {
+				t.Errorf("Sign = %s, want %s", got, tt.sig)
+			}
+
+			if s := new(big.Int).SetBytes(sig[32:64]); s.Cmp(halfOrder) > 0 {
+				t.Errorf("Sign returned high S %x", s)
+			}
+
+			if sig[64] > 1 {
+				t.Errorf("Sign returned recovery id %d", sig[64])
+			}
+		})
+	}
+}
+
+func TestRecoverAddress(t *testing.T) {
+
+	key := mustKey(t, testKeyHex)
+	digest := mustHex(t, testTxID)
+
+	sig, err := key.Sign(digest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, err := RecoverAddress(digest, sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if address != key.Address() {
+		t.Errorf("RecoverAddress = %s, want %s", address, key.Address())
+	}
+
+	// Recovery ids offset by 27, as some signers return them, are accepted.
+	legacy := bytes.Clone(sig)
+	legacy[64] += 27
+
+	address, err = RecoverAddress(digest, legacy)
+	if err != nil || address != key.Address() {
+		t.Errorf("RecoverAddress with v+27 = %s, %v, want %s", address, err, key.Address())
+	}
+
+	other := bytes.Clone(digest)
+	other[0] ^= 1
+
+	address, err = RecoverAddress(other, sig)
+	if err == nil && address == key.Address() {
+		t.Error("RecoverAddress over another digest returned the signer")
+	}
+
+	_, err = RecoverAddress(digest, sig[:64])
+	if err == nil {
+		t.Error("RecoverAddress accepted a 64-byte signature")
+	}
+}
+
+func TestSignRawDataHex(t *testing.T) {
+
+	txID, signed, err := SignRawDataHex(testRawDataHex, mustKey(t, testKeyHex))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if txID != testTxID {
+		t.Errorf("txID = %s, want %s", txID, testTxID)
+	}
+
+	tx := testTransaction(t)
+	tx.Signature = [][]byte{mustHex(t, testTxSig)}
+
+	want, err := EncodeTransaction(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if signed != want {
+		t.Errorf("signed = %s, want %s", signed, want)
+	}
+}
+
+func sha256Sum(s string) []byte {
+	sum := sha256.Sum256([]byte(s))
+	return sum[:]
+}
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
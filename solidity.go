@@ -0,0 +1,104 @@
+package trongrid
+
+import (
+	"context"
+
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/api"
+	"google.golang.org/grpc"
+)
+
+// Path prefixes of the full node, PBFT and solidity node HTTP APIs.
+const (
+	walletPath         = "/wallet"
+	walletPBFTPath     = "/walletpbft"
+	walletSolidityPath = "/walletsolidity"
+)
+
+// SolidityClient reads confirmed state only: blocks, accounts, contract
+// calls and transaction info as of the latest solidified block, which can
+// no longer be reverted. Transactions that are not solidified yet are
+// reported as not found.
+//
+// It is a distinct type from Client so that code which must only act on
+// irreversible data, such as crediting deposits, can require it.
+type SolidityClient interface {
+	GetNowBlock(ctx context.Context) (*Block, error)
+	GetBlockByNumber(ctx context.Context, number uint64) (*Block, error)
+	GetBlock(ctx context.Context, idOrNum string, detail bool) (*Block, error)
+	GetAccount(ctx context.Context, address Address) (*Account, error)
+	TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error)
+	GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error)
+}
+
+type solidityClient struct {
+	c *client
+}
+
+// NewSolidity returns a SolidityClient using the /walletsolidity API. It
+// takes the same options as New.
+func NewSolidity(opts ...ClientOption) SolidityClient {
+	return &solidityClient{c: New(opts...).(*client)}
+}
+
+func (s *solidityClient) GetNowBlock(ctx context.Context) (*Block, error) {
+	return s.c.getNowBlock(ctx, walletSolidityPath)
+}
+
+func (s *solidityClient) GetBlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	return s.c.getBlockByNumber(ctx, walletSolidityPath, number)
+}
+
+func (s *solidityClient) GetBlock(ctx context.Context, idOrNum string, detail bool) (*Block, error) {
+	return s.c.getBlock(ctx, walletSolidityPath, idOrNum, detail)
+}
+
+func (s *solidityClient) GetAccount(ctx context.Context, address Address) (*Account, error) {
+	return s.c.getAccount(ctx, walletSolidityPath, address)
+}
+
+func (s *solidityClient) TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+	return s.c.triggerConstantContract(ctx, walletSolidityPath, req)
+}
+
+func (s *solidityClient) GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error) {
+	return s.c.getTransactionInfoByID(ctx, walletSolidityPath, txID)
+}
+
+type grpcSolidityClient struct {
+	c        *grpcClient
+	solidity grpcTarget
+}
+
+// NewGRPCSolidity returns a SolidityClient using the WalletSolidity gRPC
+// service, e.g. grpc.trongrid.io:50052. It takes the same options as
+// NewGRPC.
+func NewGRPCSolidity(conn grpc.ClientConnInterface, opts ...ClientOption) SolidityClient {
+	return &grpcSolidityClient{
+		c:        NewGRPC(conn, opts...).(*grpcClient),
+		solidity: grpcTarget{reader: api.NewWalletSolidityClient(conn), service: walletSolidityService, level: ConsistencySolidified},
+	}
+}
+
+func (s *grpcSolidityClient) GetNowBlock(ctx context.Context) (*Block, error) {
+	return s.c.getNowBlock(ctx, s.solidity)
+}
+
+func (s *grpcSolidityClient) GetBlockByNumber(ctx context.Context, number uint64) (*Block, error) {
+	return s.c.getBlockByNumber(ctx, s.solidity, number)
+}
+
+func (s *grpcSolidityClient) GetBlock(ctx context.Context, idOrNum string, detail bool) (*Block, error) {
+	return s.c.getBlock(ctx, s.solidity, idOrNum, detail)
+}
+
+func (s *grpcSolidityClient) GetAccount(ctx context.Context, address Address) (*Account, error) {
+	return s.c.getAccount(ctx, s.solidity, address)
+}
+
+func (s *grpcSolidityClient) TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error) {
+	return s.c.triggerConstantContract(ctx, s.solidity, req)
+}
+
+func (s *grpcSolidityClient) GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error) {
+	return s.c.getTransactionInfoByID(ctx, s.solidity, txID)
+}
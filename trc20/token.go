@@ -0,0 +1,189 @@
+package trc20
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/signer"
+)
+
+var ErrInvalidResult = errors.New("invalid contract call result")
+
+// Token reads a TRC20 token through constant contract calls. Name, symbol
+// and decimals never change and are fetched once.
+type Token struct {
+	client   trongrid.Client
+	contract trongrid.Address
+	caller   trongrid.Address
+
+	mu       sync.Mutex
+	name     *string
+	symbol   *string
+	decimals *int
+}
+
+// NewToken returns a handle on the token at contract. Constant calls are
+// made with the contract itself as caller unless set with SetCaller.
+func NewToken(client trongrid.Client, contract trongrid.Address) *Token {
+	return &Token{client: client, contract: contract, caller: contract}
+}
+
+// SetCaller sets the owner address of constant calls, for tokens whose
+// views depend on msg.sender.
+func (t *Token) SetCaller(caller trongrid.Address) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.caller = caller
+}
+
+// Address returns the contract address of the token.
+func (t *Token) Address() trongrid.Address {
+	return t.contract
+}
+
+func (t *Token) Name(ctx context.Context) (string, error) {
+	return t.cachedString(ctx, "name", &t.name)
+}
+
+func (t *Token) Symbol(ctx context.Context) (string, error) {
+	return t.cachedString(ctx, "symbol", &t.symbol)
+}
+
+func (t *Token) Decimals(ctx context.Context) (int, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.decimals != nil {
+		return *t.decimals, nil
+	}
+
+	value, err := t.callUint(ctx, t.caller, "decimals")
+	if err != nil {
+		return 0, err
+	}
+
+	if !value.IsInt64() || value.Int64() > trongrid.MaxDecimals {
+		return 0, fmt.Errorf("%w: decimals %s", ErrInvalidResult, value)
+	}
+
+	decimals := int(value.Int64())
+	t.decimals = &decimals
+
+	return decimals, nil
+}
+
+func (t *Token) TotalSupply(ctx context.Context) (trongrid.Amount, error) {
+	return t.amount(ctx, "totalSupply")
+}
+
+func (t *Token) BalanceOf(ctx context.Context, owner trongrid.Address) (trongrid.Amount, error) {
+	return t.amount(ctx, "balanceOf", owner)
+}
+
+func (t *Token) Allowance(ctx context.Context, owner, spender trongrid.Address) (trongrid.Amount, error) {
+	return t.amount(ctx, "allowance", owner, spender)
+}
+
+// ParseAmount parses a decimal amount such as "1.5" with the decimals of
+// the token.
+func (t *Token) ParseAmount(ctx context.Context, s string) (trongrid.Amount, error) {
+
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return trongrid.Amount{}, err
+	}
+
+	return trongrid.ParseAmount(s, decimals)
+}
+
+// Send transfers amount of the token to an address, see Send.
+func (t *Token) Send(ctx context.Context, s signer.Signer, to trongrid.Address, amount trongrid.Amount, opts ...SendOption) (*Pending, error) {
+	return Send(ctx, t.client, s, t.contract, to, amount, opts...)
+}
+
+func (t *Token) amount(ctx context.Context, method string, args ...interface{}) (trongrid.Amount, error) {
+
+	decimals, err := t.Decimals(ctx)
+	if err != nil {
+		return trongrid.Amount{}, err
+	}
+
+	t.mu.Lock()
+	caller := t.caller
+	t.mu.Unlock()
+
+	value, err := t.callUint(ctx, caller, method, args...)
+	if err != nil {
+		return trongrid.Amount{}, err
+	}
+
+	return trongrid.NewAmount(value, decimals), nil
+}
+
+func (t *Token) callUint(ctx context.Context, caller trongrid.Address, method string, args ...interface{}) (*big.Int, error) {
+
+	values, err := ABI.Methods[method].Call(ctx, t.client, caller, t.contract, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s returned %T", ErrInvalidResult, method, values[0])
+	}
+
+	return value, nil
+}
+
+// cachedString calls a string view once. Some early tokens return name
+// and symbol as bytes32, which is decoded with its zero padding trimmed.
+func (t *Token) cachedString(ctx context.Context, method string, cached **string) (string, error) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if *cached != nil {
+		return **cached, nil
+	}
+
+	m := ABI.Methods[method]
+
+	req, err := m.ConstantCall(t.caller, t.contract)
+	if err != nil {
+		return "", err
+	}
+
+	response, err := t.client.TriggerConstantContract(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := m.Result(response)
+	if err != nil {
+		return "", err
+	}
+
+	var s string
+	if len(result) == 32 {
+		s = strings.TrimRight(string(result), "\x00")
+	} else {
+		values, err := m.Unpack(result)
+		if err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidResult, err)
+		}
+
+		s, _ = values[0].(string)
+	}
+
+	*cached = &s
+
+	return s, nil
+}
@@ -0,0 +1,158 @@
+package trc20
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/abi"
+)
+
+var (
+	usdt  = trongrid.MustParseAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	owner = trongrid.MustParseAddress("TNPeeaaFB7K9cmo4uQpcU32zGK8G1NYqeL")
+)
+
+// callClient answers TriggerConstantContract with the node responses in
+// responses by function selector, and records the callers. Other methods
+// are not implemented.
+type callClient struct {
+	trongrid.Client
+	responses map[string]string
+
+	mu      sync.Mutex
+	callers []trongrid.Address
+}
+
+func (c *callClient) TriggerConstantContract(ctx context.Context, req *trongrid.TriggerConstantContractRequest) (*trongrid.TriggerConstantContractResponse, error) {
+
+	c.mu.Lock()
+	c.callers = append(c.callers, req.OwnerAddress)
+	c.mu.Unlock()
+
+	var response trongrid.TriggerConstantContractResponse
+	err := json.Unmarshal([]byte(c.responses[req.FunctionSelector]), &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// constantResult returns a successful node response returning data.
+func constantResult(data string) string {
+	return fmt.Sprintf(`{"result":{"result":true},"constant_result":["%s"],"transaction":{"ret":[{}]}}`, data)
+}
+
+func TestTokenBalanceOf(t *testing.T) {
+
+	client := &callClient{responses: map[string]string{
+		"decimals()":         constantResult("0000000000000000000000000000000000000000000000000000000000000006"),
+		"balanceOf(address)": constantResult("00000000000000000000000000000000000000000000000000000000001e8480"),
+	}}
+
+	balance, err := NewToken(client, usdt).BalanceOf(context.Background(), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if balance.String() != "2" {
+		t.Errorf("BalanceOf = %s, want 2", balance)
+	}
+}
+
+func TestTokenReverted(t *testing.T) {
+
+	reason, err := abi.Arguments{{Type: abi.Type{Kind: abi.KindString}}}.Encode("balance query paused")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		response string
+		message  string
+	}{
+		{
+			name:     "with reason",
+			response: `{"result":{"result":true,"message":"REVERT opcode executed"},"constant_result":["08c379a0` + hex.EncodeToString(reason) + `"],"transaction":{"ret":[{"ret":"FAILED"}]}}`,
+			message:  "balance query paused",
+		},
+		{
+			// A bare revert returns no data, which would decode as a
+			// zero balance.
+			name:     "without reason",
+			response: `{"result":{"result":true},"constant_result":[""],"transaction":{"ret":[{"ret":"FAILED"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			client := &callClient{responses: map[string]string{
+				"decimals()":         constantResult("0000000000000000000000000000000000000000000000000000000000000006"),
+				"balanceOf(address)": tt.response,
+				"symbol()":           tt.response,
+			}}
+
+			token := NewToken(client, usdt)
+
+			_, err := token.BalanceOf(context.Background(), owner)
+			if !errors.Is(err, abi.ErrReverted) || !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("BalanceOf error = %v, want a revert with %q", err, tt.message)
+			}
+
+			_, err = token.Symbol(context.Background())
+			if !errors.Is(err, abi.ErrReverted) {
+				t.Errorf("Symbol error = %v, want a revert", err)
+			}
+		})
+	}
+}
+
+func TestTokenSetCaller(t *testing.T) {
+
+	client := &callClient{responses: map[string]string{
+		"decimals()":         constantResult("0000000000000000000000000000000000000000000000000000000000000006"),
+		"balanceOf(address)": constantResult("0000000000000000000000000000000000000000000000000000000000000001"),
+	}}
+
+	token := NewToken(client, usdt)
+
+	// Run with -race: SetCaller may be called while other calls are made.
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			token.SetCaller(owner)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := token.BalanceOf(context.Background(), owner)
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	_, err := token.BalanceOf(context.Background(), owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if last := client.callers[len(client.callers)-1]; last != owner {
+		t.Errorf("called from %s, want %s", last, owner)
+	}
+}
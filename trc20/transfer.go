@@ -0,0 +1,197 @@
+// Package trc20 sends and reads TRC20 tokens such as USDT.
+package trc20
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+	"github.com/TheTeaParty/trongrid/signer"
+	"github.com/TheTeaParty/trongrid/txbuilder"
+)
+
+// DefaultFeeLimit is the fee limit of transfers unless set with
+// WithFeeLimit. USDT transfers burn up to about 30 TRX of energy.
+const DefaultFeeLimit = 100 * trongrid.SunPerTRX
+
+// DefaultPollInterval is how often Wait polls for the transaction info,
+// about once per block.
+const DefaultPollInterval = 3 * time.Second
+
+var (
+	ErrBroadcastRejected = errors.New("transaction rejected by the node")
+	ErrTransactionFailed = errors.New("transaction failed")
+)
+
+// TransferData returns the call data of transfer(to, amount).
+func TransferData(to trongrid.Address, amount *big.Int) ([]byte, error) {
+	return ABI.Pack("transfer", to, amount)
+}
+
+// Transfer returns a builder for a transfer of amount base units of the
+// token at contract from one address to another, with DefaultFeeLimit.
+func Transfer(contract, from, to trongrid.Address, amount trongrid.Amount) (*txbuilder.Builder, error) {
+
+	data, err := TransferData(to, amount.Int())
+	if err != nil {
+		return nil, err
+	}
+
+	return txbuilder.New(core.Transaction_Contract_TriggerSmartContract, &core.TriggerSmartContract{
+		OwnerAddress:    from.Bytes(),
+		ContractAddress: contract.Bytes(),
+		Data:            data,
+	}).FeeLimit(DefaultFeeLimit), nil
+}
+
+type sendOptions struct {
+	feeLimit     trongrid.Sun
+	memo         string
+	permissionID int
+	expiration   time.Duration
+	pollInterval time.Duration
+}
+
+type SendOption func(*sendOptions)
+
+// WithFeeLimit sets the maximum TRX burnt for energy by the transfer.
+func WithFeeLimit(limit trongrid.Sun) SendOption {
+	return func(o *sendOptions) {
+		o.feeLimit = limit
+	}
+}
+
+func WithMemo(memo string) SendOption {
+	return func(o *sendOptions) {
+		o.memo = memo
+	}
+}
+
+// WithPermissionID signs the transfer with an active permission of the
+// sender rather than the owner permission.
+func WithPermissionID(id int) SendOption {
+	return func(o *sendOptions) {
+		o.permissionID = id
+	}
+}
+
+func WithExpiration(d time.Duration) SendOption {
+	return func(o *sendOptions) {
+		o.expiration = d
+	}
+}
+
+// WithPollInterval sets how often Wait polls for the transaction info.
+// Durations that are not positive select DefaultPollInterval.
+func WithPollInterval(d time.Duration) SendOption {
+	return func(o *sendOptions) {
+		o.pollInterval = d
+	}
+}
+
+// Send transfers amount of the token at contract to an address. The
+// transfer is built on top of the current block, signed by s, which also
+// pays the fee, and broadcast. The returned Pending is broadcast but not
+// confirmed yet.
+func Send(ctx context.Context, client trongrid.Client, s signer.Signer, contract, to trongrid.Address, amount trongrid.Amount, opts ...SendOption) (*Pending, error) {
+
+	options := &sendOptions{
+		feeLimit:     DefaultFeeLimit,
+		expiration:   txbuilder.DefaultExpiration,
+		pollInterval: DefaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	builder, err := Transfer(contract, s.Address(), to, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	builder.FeeLimit(options.feeLimit).Expiration(options.expiration).PermissionID(options.permissionID)
+	if options.memo != "" {
+		builder.Memo(options.memo)
+	}
+
+	ref, err := client.GetNowBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txID, txHex, err := builder.BuildSigned(ctx, ref, s)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := client.BroadcastHex(ctx, &trongrid.BroadcastHexRequest{Transaction: txHex})
+	if err != nil {
+		return nil, err
+	}
+
+	if !response.Result {
+		return nil, fmt.Errorf("%w: %s %s", ErrBroadcastRejected, response.Code, response.Message)
+	}
+
+	return &Pending{TxID: txID, client: client, pollInterval: options.pollInterval}, nil
+}
+
+// Pending is a broadcast transaction.
+type Pending struct {
+	TxID string
+
+	client       trongrid.Client
+	pollInterval time.Duration
+}
+
+// Wait polls until the transaction is included in a block and returns its
+// info. If the transfer was included but failed, e.g. because it ran out
+// of energy or the contract reverted, the info is returned along with an
+// error wrapping ErrTransactionFailed. Wait gives up when ctx is done;
+// callers should use a deadline past the transaction expiration.
+func (p *Pending) Wait(ctx context.Context) (*trongrid.GetTransactionInfoByIDResponse, error) {
+
+	interval := p.pollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		info, err := p.client.GetTransactionInfoByID(ctx, p.TxID)
+		if err == nil {
+			return info, receiptError(info)
+		}
+
+		if !errors.Is(err, trongrid.ErrNotFound) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func receiptError(info *trongrid.GetTransactionInfoByIDResponse) error {
+
+	if !info.Failed() {
+		return nil
+	}
+
+	message := string(info.Receipt.Result)
+	if info.ResMessage != "" {
+		message += ": " + info.ResMessage
+	}
+
+	return fmt.Errorf("%w: %s", ErrTransactionFailed, message)
+}
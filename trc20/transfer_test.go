@@ -0,0 +1,79 @@
+package trc20
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TheTeaParty/trongrid"
+)
+
+// infoClient answers GetTransactionInfoByID with ErrNotFound until the
+// transaction is found on call found. Other methods are not implemented.
+type infoClient struct {
+	trongrid.Client
+	found int
+	calls int
+}
+
+func (c *infoClient) GetTransactionInfoByID(ctx context.Context, txID string) (*trongrid.GetTransactionInfoByIDResponse, error) {
+
+	c.calls++
+	if c.found == 0 || c.calls < c.found {
+		return nil, trongrid.ErrNotFound
+	}
+
+	return &trongrid.GetTransactionInfoByIDResponse{Id: txID}, nil
+}
+
+func TestPendingWaitPollInterval(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		interval time.Duration
+	}{
+		{name: "zero", interval: 0},
+		{name: "negative", interval: -time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			// The option keeps the interval; Wait falls back to the default.
+			options := &sendOptions{}
+			WithPollInterval(tt.interval)(options)
+
+			client := &infoClient{}
+			p := &Pending{TxID: "00", client: client, pollInterval: options.pollInterval}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+
+			_, err := p.Wait(ctx)
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Wait error = %v, want the deadline", err)
+			}
+
+			// The default interval is far longer than the deadline.
+			if client.calls != 1 {
+				t.Errorf("polled %d times, want 1", client.calls)
+			}
+		})
+	}
+}
+
+func TestPendingWait(t *testing.T) {
+
+	client := &infoClient{found: 3}
+	p := &Pending{TxID: "00", client: client, pollInterval: time.Millisecond}
+
+	info, err := p.Wait(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Id != "00" || client.calls != 3 {
+		t.Errorf("got %q after %d polls, want 00 after 3", info.Id, client.calls)
+	}
+}
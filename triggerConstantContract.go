@@ -1,16 +1,40 @@
 package trongrid
 
+import "encoding/json"
+
 type TriggerConstantContractRequest struct {
-	OwnerAddress     string `json:"owner_address"`
-	ContractAddress  string `json:"contract_address"`
-	FunctionSelector string `json:"function_selector"`
-	Parameter        string `json:"parameter"`
-	Visible          bool   `json:"visible"`
+	OwnerAddress     Address `json:"owner_address"`
+	ContractAddress  Address `json:"contract_address"`
+	FunctionSelector string  `json:"function_selector"`
+	Parameter        string  `json:"parameter"`
+	Visible          bool    `json:"visible"`
+}
+
+// MarshalJSON encodes the addresses in Base58Check form if Visible is set
+// and in hex form otherwise, as the node expects.
+func (r TriggerConstantContractRequest) MarshalJSON() ([]byte, error) {
+
+	type request TriggerConstantContractRequest
+
+	if r.Visible {
+		return json.Marshal(request(r))
+	}
+
+	return json.Marshal(struct {
+		request
+		OwnerAddress    string `json:"owner_address"`
+		ContractAddress string `json:"contract_address"`
+	}{
+		request:         request(r),
+		OwnerAddress:    r.OwnerAddress.Hex(),
+		ContractAddress: r.ContractAddress.Hex(),
+	})
 }
 
 type TriggerConstantContractResponse struct {
 	Result struct {
 		Result  bool   `json:"result"`
+		Code    string `json:"code"`
 		Message string `json:"message"`
 	} `json:"result"`
 	EnergyUsed     int      `json:"energy_used"`
@@ -18,7 +42,8 @@ type TriggerConstantContractResponse struct {
 	EnergyPenalty  int      `json:"energy_penalty"`
 	Transaction    struct {
 		Ret []struct {
-			Ret string `json:"ret"`
+			Ret         string `json:"ret"`
+			ContractRet string `json:"contractRet"`
 		} `json:"ret"`
 		Visible bool   `json:"visible"`
 		TxID    string `json:"txID"`
@@ -26,9 +51,9 @@ type TriggerConstantContractResponse struct {
 			Contract []struct {
 				Parameter struct {
 					Value struct {
-						Data            string `json:"data"`
-						OwnerAddress    string `json:"owner_address"`
-						ContractAddress string `json:"contract_address"`
+						Data            string  `json:"data"`
+						OwnerAddress    Address `json:"owner_address"`
+						ContractAddress Address `json:"contract_address"`
 					} `json:"value"`
 					TypeUrl string `json:"type_url"`
 				} `json:"parameter"`
@@ -41,4 +66,27 @@ type TriggerConstantContractResponse struct {
 		} `json:"raw_data"`
 		RawDataHex string `json:"raw_data_hex"`
 	} `json:"transaction"`
+	// Consistency is the confirmation level of the state the call was
+	// made on.
+	Consistency Consistency `json:"-"`
+}
+
+// Reverted reports whether the call failed while executing, e.g. because
+// the contract reverted. The node still reports a successful Result; the
+// revert data, if any, is in ConstantResult.
+func (r *TriggerConstantContractResponse) Reverted() bool {
+
+	for _, ret := range r.Transaction.Ret {
+		if ret.Ret == "FAILED" {
+			return true
+		}
+
+		switch ContractResult(ret.ContractRet) {
+		case "", ContractResultDefault, ContractResultSuccess:
+		default:
+			return true
+		}
+	}
+
+	return false
 }
@@ -3,11 +3,20 @@ package trongrid
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
+	"time"
 )
 
 var (
-	ErrNoDataInResponse = errors.New("no data in response")
+	ErrNoDataInResponse   = errors.New("no data in response")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrNotFound           = errors.New("not found")
+	ErrBadSignature       = errors.New("bad signature")
+	ErrBandwidthExhausted = errors.New("bandwidth exhausted")
+	ErrTransactionExpired = errors.New("transaction expired")
+	ErrNotSupported       = errors.New("not supported")
+	ErrNoEndpoint         = errors.New("no endpoint available")
 )
 
 const (
@@ -26,14 +35,21 @@ const (
 
 type Client interface {
 	GetNowBlock(ctx context.Context) (*Block, error)
-	GetAccountBalance(ctx context.Context, address string, blockNumber uint64, blockHash string) (*AccountBalance, error)
+	GetAccountBalance(ctx context.Context, address Address, blockNumber uint64, blockHash string) (*AccountBalance, error)
 	GetBlockByNumber(ctx context.Context, number uint64) (*Block, error)
-	GetAccount(ctx context.Context, address string) (*Account, error)
-	GetAccountTransactions(ctx context.Context, address string, opts ...GetAccountTransactionsOption) (*GetAccountTransactionsCursor, error)
+	GetBlock(ctx context.Context, idOrNum string, detail bool) (*Block, error)
+	// GetBlocksByRange returns the blocks numbered from start up to but
+	// not including end, in ascending order. end must not be below start.
+	GetBlocksByRange(ctx context.Context, start, end uint64) ([]*Block, error)
+	// GetLatestBlocks returns the n latest blocks in ascending order.
+	GetLatestBlocks(ctx context.Context, n int) ([]*Block, error)
+	GetAccount(ctx context.Context, address Address) (*Account, error)
+	GetAccountTransactions(ctx context.Context, address Address, opts ...GetAccountTransactionsOption) (*GetAccountTransactionsCursor, error)
 	BroadcastHex(ctx context.Context, req *BroadcastHexRequest) (*BroadcastHexResponse, error)
 	TriggerConstantContract(ctx context.Context, req *TriggerConstantContractRequest) (*TriggerConstantContractResponse, error)
-	GetContractTransaction(ctx context.Context, address, contractType string, opts ...GetContractTransactionOption) (*GetContractTransactionCursor, error)
+	GetContractTransaction(ctx context.Context, address Address, contractType string, opts ...GetContractTransactionOption) (*GetContractTransactionCursor, error)
 	GetTransactionInfoByID(ctx context.Context, txID string) (*GetTransactionInfoByIDResponse, error)
+	GetContract(ctx context.Context, address Address) (*SmartContract, error)
 }
 
 type RateLimiter interface {
@@ -47,6 +63,18 @@ type clientOptions struct {
 	httpClient      *http.Client
 	apiKey          string
 	rateLimiter     RateLimiter
+	retryPolicy     RetryPolicy
+	keyPool         *KeyPool
+	middlewares     []Middleware
+	logger          *slog.Logger
+	logBodyLimit    int
+	cache           Cache
+	consistency     Consistency
+
+	endpoints           []Endpoint
+	healthCheckInterval time.Duration
+	maxBlockLag         int64
+	stickyCursors       bool
 }
 
 type ClientOption func(*clientOptions)
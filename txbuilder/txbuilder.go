@@ -0,0 +1,174 @@
+// Package txbuilder builds TRON transactions locally from the protobuf
+// core types, without calling /wallet/createtransaction.
+//
+// A transaction references a recent block, usually the one returned by
+// GetNowBlock, and is only valid while that block is among the last 65536
+// blocks and before its expiration.
+package txbuilder
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TheTeaParty/trongrid"
+	"github.com/TheTeaParty/trongrid/pkg/tronpb/core"
+	"github.com/TheTeaParty/trongrid/signer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// DefaultExpiration is how long after the reference block a transaction
+// expires unless set with Expiration.
+const DefaultExpiration = time.Minute
+
+// MaxExpiration is the longest expiration the node accepts.
+const MaxExpiration = 24 * time.Hour
+
+var ErrInvalidRefBlock = errors.New("invalid reference block")
+
+// Builder builds a single-contract transaction.
+type Builder struct {
+	contractType core.Transaction_Contract_ContractType
+	parameter    proto.Message
+
+	memo         []byte
+	permissionID int32
+	expiration   time.Duration
+	feeLimit     trongrid.Sun
+	now          func() time.Time
+}
+
+// New returns a builder for a contract of the given type, parameter being
+// the matching message, e.g. *core.TransferContract.
+func New(contractType core.Transaction_Contract_ContractType, parameter proto.Message) *Builder {
+	return &Builder{
+		contractType: contractType,
+		parameter:    parameter,
+		expiration:   DefaultExpiration,
+		now:          time.Now,
+	}
+}
+
+// Transfer returns a builder for a transfer of amount from one address to
+// another.
+func Transfer(from, to trongrid.Address, amount trongrid.Sun) *Builder {
+	return New(core.Transaction_Contract_TransferContract, &core.TransferContract{
+		OwnerAddress: from.Bytes(),
+		ToAddress:    to.Bytes(),
+		Amount:       int64(amount),
+	})
+}
+
+// Memo sets the data field of the transaction, shown as a note by wallets
+// and explorers. Memos cost extra bandwidth and, on mainnet, a fee.
+func (b *Builder) Memo(memo string) *Builder {
+	b.memo = []byte(memo)
+	return b
+}
+
+// PermissionID sets the account permission the transaction is signed
+// with: 0 for owner, 2 and above for active permissions.
+func (b *Builder) PermissionID(id int) *Builder {
+	b.permissionID = int32(id)
+	return b
+}
+
+// Expiration sets how long after the reference block the transaction
+// expires.
+func (b *Builder) Expiration(d time.Duration) *Builder {
+	b.expiration = d
+	return b
+}
+
+// FeeLimit sets the maximum fee burnt for energy by smart contract calls.
+func (b *Builder) FeeLimit(limit trongrid.Sun) *Builder {
+	b.feeLimit = limit
+	return b
+}
+
+// Build returns the unsigned transaction referencing ref.
+func (b *Builder) Build(ref *trongrid.Block) (*core.Transaction, error) {
+
+	if b.expiration <= 0 || b.expiration > MaxExpiration {
+		return nil, fmt.Errorf("expiration %s out of range", b.expiration)
+	}
+
+	refBlockBytes, refBlockHash, err := RefBlock(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	parameter, err := anypb.New(b.parameter)
+	if err != nil {
+		return nil, err
+	}
+
+	refTime := time.UnixMilli(ref.BlockHeader.RawData.Timestamp)
+
+	return &core.Transaction{
+		RawData: &core.TransactionRaw{
+			RefBlockBytes: refBlockBytes,
+			RefBlockHash:  refBlockHash,
+			Expiration:    refTime.Add(b.expiration).UnixMilli(),
+			Data:          b.memo,
+			Contract: []*core.Transaction_Contract{{
+				Type:         b.contractType,
+				Parameter:    parameter,
+				PermissionId: b.permissionID,
+			}},
+			Timestamp: b.now().UnixMilli(),
+			FeeLimit:  int64(b.feeLimit),
+		},
+	}, nil
+}
+
+// BuildSigned builds the transaction, signs it with s and returns its id
+// and the hex BroadcastHex expects.
+func (b *Builder) BuildSigned(ctx context.Context, ref *trongrid.Block, s signer.Signer) (txID string, txHex string, err error) {
+
+	tx, err := b.Build(ref)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = signer.Sign(ctx, tx, s)
+	if err != nil {
+		return "", "", err
+	}
+
+	id, err := signer.TransactionID(tx)
+	if err != nil {
+		return "", "", err
+	}
+
+	txHex, err = signer.EncodeTransaction(tx)
+	if err != nil {
+		return "", "", err
+	}
+
+	return hex.EncodeToString(id), txHex, nil
+}
+
+// RefBlock returns the ref_block_bytes and ref_block_hash of a transaction
+// referencing block: bytes 6 to 8 of its big-endian number and bytes 8 to
+// 16 of its id.
+func RefBlock(block *trongrid.Block) (refBlockBytes []byte, refBlockHash []byte, err error) {
+
+	if block == nil || block.BlockHeader == nil || block.BlockHeader.RawData == nil {
+		return nil, nil, fmt.Errorf("%w: no header", ErrInvalidRefBlock)
+	}
+
+	id, err := hex.DecodeString(block.BlockID)
+	if err != nil || len(id) != 32 {
+		return nil, nil, fmt.Errorf("%w: block id %q", ErrInvalidRefBlock, block.BlockID)
+	}
+
+	number := make([]byte, 8)
+	binary.BigEndian.PutUint64(number, uint64(block.BlockHeader.RawData.Number))
+
+	return number[6:8], id[8:16], nil
+}